@@ -1,12 +1,13 @@
 package ganim8
 
 import (
-	"fmt"
 	"image"
 	"log"
+	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/ganim8/v2/timeline"
 )
 
 type imageCache map[*ebiten.Image]map[*image.Rectangle]*ebiten.Image
@@ -15,92 +16,93 @@ var _imageCache imageCache
 
 var DefaultDelta = time.Millisecond * 16
 
+// DefaultTick is the length of a tick of the animations in tick
+// mode: one update of Ebitengine at the default 60 TPS.
+var DefaultTick = time.Second / 60
+
+// TimeScale is the multiplier applied to DefaultDelta by Update,
+// e.g. 0.5 for slow motion in every animation updated with it.
+var TimeScale = 1.0
+
 func init() {
 	_imageCache = make(map[*ebiten.Image]map[*image.Rectangle]*ebiten.Image)
 }
 
 func parseDurations(durations interface{}, frameCount int) []time.Duration {
-	result := make([]time.Duration, frameCount)
-	switch val := durations.(type) {
-	case time.Duration:
-		for i := 0; i < frameCount; i++ {
-			result[i] = val
-		}
-	case []time.Duration:
-		for i := range val {
-			result[i] = val[i]
-		}
-	case []interface{}:
-		for i := range val {
-			result[i] = parseDurationValue(val[i])
-		}
-	case map[string]time.Duration:
-		for key, duration := range val {
-			min, max, step := parseInterval(key)
-			for i := min; i <= max; i += step {
-				result[i-1] = duration
-			}
-		}
-	case map[string]interface{}:
-		for key, duration := range val {
-			min, max, step := parseInterval(key)
-			for i := min; i <= max; i += step {
-				result[i-1] = parseDurationValue(duration)
-			}
-		}
-	case interface{}:
-		for i := 0; i < frameCount; i++ {
-			result[i] = parseDurationValue(val)
-		}
-	default:
-		log.Fatal(fmt.Sprintf("failed to parse durations: type=%T val=%+v", durations, durations))
+	result, err := tryParseDurations(durations, frameCount)
+	if err != nil {
+		log.Fatal(err)
 	}
 	return result
 }
 
-func parseDurationValue(value interface{}) time.Duration {
-	switch val := value.(type) {
-	case time.Duration:
-		return val
-	case int:
-		return time.Millisecond * time.Duration(val)
-	case float64:
-		return time.Millisecond * time.Duration(val)
-	default:
-		log.Fatal(fmt.Sprintf("failed to parse duration value: %+v", value))
-	}
-	return 0
+func tryParseDurations(durations interface{}, frameCount int) ([]time.Duration, error) {
+	return timeline.ParseDurations(durations, frameCount)
 }
 
-func parseIntervals(durations []time.Duration) ([]time.Duration, time.Duration) {
-	result := []time.Duration{0}
-	var time time.Duration = 0
-	for _, v := range durations {
-		time += v
-		result = append(result, time)
+func parseTicks(ticks interface{}, frameCount int, tick time.Duration) []time.Duration {
+	result, err := timeline.ParseTickDurations(ticks, frameCount, tick)
+	if err != nil {
+		log.Fatal(err)
 	}
-	return result, time
+	return result
 }
 
 // Status represents the animation status.
-type Status int
+type Status = timeline.Status
 
 const (
 	Playing = iota
 	Paused
 )
 
+// Direction represents the direction in which the frames of an
+// animation are played.
+type Direction = timeline.Direction
+
+const (
+	// Forward plays the frames from the first to the last.
+	Forward = timeline.Forward
+	// Reverse plays the frames from the last to the first.
+	Reverse = timeline.Reverse
+	// PingPong plays the frames from the first to the last and
+	// then back, without repeating the first and the last frame.
+	PingPong = timeline.PingPong
+	// PingPongReverse plays the frames from the last to the first
+	// and then back, without repeating the first and the last frame.
+	PingPongReverse = timeline.PingPongReverse
+)
+
 // Animation represents an animation created from specified frames
 // and an *ebiten.Image
 type Animation struct {
-	sprite        *Sprite
-	position      int
-	timer         time.Duration
-	durations     []time.Duration
-	intervals     []time.Duration
-	totalDuration time.Duration
-	onLoop        OnLoop
-	status        Status
+	sprite     *Sprite
+	timeline   *timeline.Timeline
+	onLoop     OnLoop
+	onComplete OnComplete
+	onEvent    OnEvent
+
+	name string
+
+	subscribers      []subscriber
+	lastSubscription int
+	started          bool
+
+	motion           []motion
+	motionX, motionY float64
+	motionIndex      int
+	backward         bool
+	flipH, flipV     bool
+
+	tracks    []*Track
+	trackOpts DrawOptions
+
+	loaded *loadedAnimation
+	synced loadedAnimation
+
+	group      *Group
+	groupNow   time.Duration
+	groupTicks int
 }
 
 // OnLoop is callback function which representing
@@ -121,6 +123,15 @@ type OnLoop func(anim *Animation, loops int)
 // Nop does nothing.
 func Nop(anim *Animation, loops int) {}
 
+// OnComplete is the callback function called when an animation
+// has played as many times as its repeat count.
+type OnComplete func(anim *Animation)
+
+// OnEvent is the callback function called when an animation enters
+// a frame with events. It has three parameters: the animation, the
+// name of the event and the position of the frame (from 1).
+type OnEvent func(anim *Animation, event string, position int)
+
 // Pause pauses the animation on loop finished.
 func Pause(anim *Animation, loops int) {
 	anim.Pause()
@@ -153,21 +164,35 @@ func PauseAtStart(anim *Animation, loops int) {
 // 100 * time.Millisecond, "3-5": 200 * time.Millisecond }.
 func NewAnimation(sprite *Sprite, durations interface{}, onLoop ...OnLoop) *Animation {
 	_durations := parseDurations(durations, sprite.length)
-	intervals, totalDuration := parseIntervals(_durations)
+	return newAnimation(sprite, timeline.New(_durations), onLoop)
+}
+
+// NewTickAnimation returns a new animation in tick mode for games
+// running in fixed ticks (lockstep or rollback netcode).
+//
+// ticks are the same as the durations of NewAnimation except that
+// numbers are counts of ticks of DefaultTick. An animation in tick
+// mode advances by exactly one tick on Update, so its playback is
+// the same on every machine.
+func NewTickAnimation(sprite *Sprite, ticks interface{}, onLoop ...OnLoop) *Animation {
+	tl, err := timeline.ParseTicks(ticks, sprite.length, DefaultTick)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return newAnimation(sprite, tl, onLoop)
+}
+
+func newAnimation(sprite *Sprite, tl *timeline.Timeline, onLoop []OnLoop) *Animation {
 	ol := Nop
 	if len(onLoop) > 0 {
 		ol = onLoop[0]
 	}
 	anim := &Animation{
-		sprite:        sprite,
-		position:      0,
-		timer:         0,
-		durations:     _durations,
-		intervals:     intervals,
-		totalDuration: totalDuration,
-		onLoop:        ol,
-		status:        Playing,
+		sprite: sprite,
+		onLoop: ol,
 	}
+	anim.timeline = tl
+	anim.bind()
 	return anim
 }
 
@@ -180,87 +205,401 @@ func New(img *ebiten.Image, frames []*image.Rectangle, durations interface{}, on
 // Clone return a copied animation object.
 func (anim *Animation) Clone() *Animation {
 	new := *anim
+	new.timeline = anim.timeline.Clone()
+	new.subscribers = nil
+	new.started = false
+	new.motion = append([]motion(nil), anim.motion...)
+	new.motionX, new.motionY = 0, 0
+	new.tracks = append([]*Track(nil), anim.tracks...)
+	new.group = nil
+	new.flipH, new.flipV = false, false
+	new.bind()
 	return &new
 }
 
+// bind sets the callbacks of the timeline to the ones of the
+// animation.
+func (anim *Animation) bind() {
+	anim.timeline.SetOnLoop(anim.loop)
+	anim.timeline.SetOnComplete(anim.complete)
+	anim.timeline.SetOnEvent(anim.event)
+	if anim.motion != nil {
+		anim.timeline.SetOnEnter(anim.enter)
+	}
+}
+
+// loop calls the onLoop callback of the animation when its
+// timeline loops.
+func (anim *Animation) loop(tl *timeline.Timeline, loops int) {
+	(anim.onLoop)(anim, loops)
+	anim.dispatch(EventLoop, loops)
+}
+
+// complete calls the onComplete callback of the animation when
+// its timeline completes.
+func (anim *Animation) complete(tl *timeline.Timeline) {
+	anim.dispatch(EventPause, 0)
+	if anim.onComplete != nil {
+		anim.onComplete(anim)
+	}
+	anim.dispatch(EventComplete, 0)
+}
+
+// event calls the onEvent callback of the animation when its
+// timeline enters a frame with events.
+func (anim *Animation) event(tl *timeline.Timeline, event string, position int) {
+	if anim.onEvent != nil {
+		anim.onEvent(anim, event, position)
+	}
+}
+
+// SetOnEvent sets the callback function called when the animation
+// enters a frame with events during UpdateWithDelta. It is called
+// for every frame entered, even the ones skipped over by a large
+// delta, in the order the frames are played. The animation is
+// already on the frame of the event, and pausing it or moving it
+// to another frame from the callback drops the rest of the delta.
+func (anim *Animation) SetOnEvent(onEvent OnEvent) {
+	anim.onEvent = onEvent
+}
+
+// AddEvent attaches the named event to frames. frames is a frame
+// number (from 1) or a range of frames like "2-4".
+func (anim *Animation) AddEvent(name string, frames interface{}) {
+	if err := anim.timeline.AddEvent(name, frames); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// RemoveEvent removes the named event from every frame.
+func (anim *Animation) RemoveEvent(name string) {
+	anim.timeline.RemoveEvent(name)
+}
+
+// Events returns the names of the events of the frame at the
+// position (from 1).
+func (anim *Animation) Events(position int) []string {
+	return anim.timeline.Events(position)
+}
+
 // SetOnLoop sets the callback function which representing
 func (anim *Animation) SetOnLoop(onLoop OnLoop) {
 	anim.onLoop = onLoop
 }
 
+// SetOnComplete sets the callback function called when the
+// animation has played as many times as its repeat count.
+func (anim *Animation) SetOnComplete(onComplete OnComplete) {
+	anim.onComplete = onComplete
+}
+
+// SetRepeat sets how many times the animation is played before
+// it pauses at the end and calls the OnComplete callback.
+// Zero (the default) repeats it forever.
+// It also resets the count of the loops played so far.
+func (anim *Animation) SetRepeat(count int) {
+	anim.timeline.SetRepeat(count)
+}
+
+// Repeat returns how many times the animation is played before
+// it completes. Zero means forever.
+func (anim *Animation) Repeat() int {
+	return anim.timeline.Repeat()
+}
+
+// Loops returns how many times the animation has looped since it
+// was started or its repeat count was set.
+func (anim *Animation) Loops() int {
+	return anim.timeline.Loops()
+}
+
+// RemainingLoops returns how many more times the animation will
+// loop before it completes, counting the one being played.
+// It returns -1 if the animation repeats forever.
+func (anim *Animation) RemainingLoops() int {
+	return anim.timeline.RemainingLoops()
+}
+
+// IsComplete returns true if the animation has been played as
+// many times as its repeat count.
+func (anim *Animation) IsComplete() bool {
+	return anim.timeline.IsComplete()
+}
+
+// Restart moves the animation to its first frame, resets the
+// count of the loops and plays it. It dispatches EventStart, then
+// EventResume if the animation was paused and EventFrameChanged if
+// it was on another frame.
+func (anim *Animation) Restart() {
+	index, paused := anim.timeline.Index(), anim.timeline.Status() == Paused
+	anim.timeline.Restart()
+	anim.started = true
+	anim.dispatch(EventStart, 0)
+	anim.startMotion()
+	if paused {
+		anim.dispatch(EventResume, 0)
+	}
+	anim.frameChanged(index)
+}
+
 func (anim *Animation) IsEnd() bool {
-	if anim.status == Paused && anim.position == anim.sprite.length-1 {
-		return true
+	return anim.timeline.IsEnd()
+}
+
+// SetDirection sets the direction in which the frames are played.
+// The animation stays on the current frame and the timer is moved
+// to the start of it, unless the timer is still zero in which case
+// the animation moves to the first frame played in the direction.
+func (anim *Animation) SetDirection(direction Direction) {
+	index := anim.timeline.Index()
+	anim.timeline.SetDirection(direction)
+	anim.frameChanged(index)
+}
+
+// Direction returns the direction in which the frames are played.
+func (anim *Animation) Direction() Direction {
+	return anim.timeline.Direction()
+}
+
+// Update updates the animation with DefaultDelta multiplied by
+// TimeScale. In tick mode it updates the animation by exactly one
+// tick instead, without TimeScale (see UpdateTicks).
+func (anim *Animation) Update() {
+	if anim.timeline.Tick() != 0 {
+		anim.UpdateTicks(1)
+		return
 	}
-	return false
+	anim.UpdateWithDelta(anim.updateDelta())
 }
 
-func seekFrameIndex(intervals []time.Duration, timer time.Duration) int {
-	high, low, i := len(intervals)-2, 0, 0
-	for low <= high {
-		i = (low + high) / 2
-		if timer >= intervals[i+1] {
-			low = i + 1
-		} else if timer < intervals[i] {
-			high = i - 1
-		} else {
-			return i
-		}
+// updateDelta returns the delta of Update.
+func (anim *Animation) updateDelta() time.Duration {
+	if tick := anim.timeline.Tick(); tick != 0 {
+		return tick
 	}
-	return i
+	return time.Duration(float64(DefaultDelta) * TimeScale)
 }
 
-// Update updates the animation.
-func (anim *Animation) Update() {
-	anim.UpdateWithDelta(DefaultDelta)
+// timeToLoopEnd returns the delta after which the animation ends
+// its current loop, or -1 if it never does at its speed.
+func (anim *Animation) timeToLoopEnd() time.Duration {
+	return anim.timeToLoops(1)
 }
 
-// UpdateWithDelta updates the animation with the specified delta.
+// timeLeftAfterComplete returns how much of the delta is left once
+// the animation completes its repeat count during an update with
+// the delta, or -1 if it doesn't complete. The delta is scaled by
+// the speed the same way as UpdateWithDelta does, so the animation
+// always completes when this is not negative.
+func (anim *Animation) timeLeftAfterComplete(elapsedTime time.Duration) time.Duration {
+	tl := anim.timeline
+	if tl.Repeat() <= 0 || tl.Status() != Playing || tl.IsComplete() {
+		return -1
+	}
+	speed := tl.Speed()
+	if speed <= 0 {
+		return -1
+	}
+	delta := elapsedTime
+	if speed != 1 {
+		delta = time.Duration(float64(elapsedTime) * speed)
+	}
+	left := tl.Cycle()*time.Duration(tl.RemainingLoops()) - tl.Timer()
+	if delta < left {
+		return -1
+	}
+	if speed != 1 {
+		return time.Duration(float64(delta-left) / speed)
+	}
+	return delta - left
+}
+
+// timeToLoops returns the delta after which the animation ends
+// the loops counting the current one, or -1 if it never does at
+// its speed.
+func (anim *Animation) timeToLoops(loops int) time.Duration {
+	tl := anim.timeline
+	if tl.Status() != Playing || tl.IsComplete() {
+		return -1
+	}
+	speed := tl.Speed()
+	if speed <= 0 {
+		return -1
+	}
+	left := tl.Cycle()*time.Duration(loops) - tl.Timer()
+	if speed != 1 {
+		left = time.Duration(math.Ceil(float64(left) / speed))
+	}
+	return left
+}
+
+// UpdateTicks updates the animation in tick mode by exactly n
+// ticks, backward if n is negative. The speed isn't applied so that
+// the timer stays a whole number of ticks.
+// It does nothing if the animation is not in tick mode. In a group,
+// it only catches the animation up with the group.
+func (anim *Animation) UpdateTicks(n int) {
+	if anim.group != nil {
+		anim.group.catchUp(anim)
+		return
+	}
+	anim.updateTicks(n)
+}
+
+func (anim *Animation) updateTicks(n int) {
+	if anim.timeline.Tick() == 0 {
+		return
+	}
+	index := anim.beforeUpdate(n < 0)
+	anim.timeline.UpdateTicks(n)
+	anim.frameChanged(index)
+}
+
+// Tick returns the length of a tick in tick mode, or zero.
+func (anim *Animation) Tick() time.Duration {
+	return anim.timeline.Tick()
+}
+
+// TickTimer returns the timer in ticks in tick mode.
+func (anim *Animation) TickTimer() int {
+	return anim.timeline.TickTimer()
+}
+
+// SetSpeed sets the multiplier applied to the deltas of the
+// animation. 1 is the normal speed, 0 freezes the animation and
+// negative values play it backward.
+func (anim *Animation) SetSpeed(speed float64) {
+	anim.timeline.SetSpeed(speed)
+}
+
+// Speed returns the multiplier applied to the deltas of the
+// animation.
+func (anim *Animation) Speed() float64 {
+	return anim.timeline.Speed()
+}
+
+// UpdateWithDelta updates the animation with the specified delta
+// multiplied by its speed. In a group, it only catches the
+// animation up with the group.
 func (anim *Animation) UpdateWithDelta(elapsedTime time.Duration) {
-	if anim.status != Playing || anim.sprite.length <= 1 {
+	if anim.group != nil {
+		anim.group.catchUp(anim)
 		return
 	}
-	anim.timer += elapsedTime
-	loops := anim.timer / anim.totalDuration
-	if loops != 0 {
-		anim.timer = anim.timer - anim.totalDuration*loops
-		(anim.onLoop)(anim, int(loops))
+	anim.updateWithDelta(elapsedTime)
+}
+
+func (anim *Animation) updateWithDelta(elapsedTime time.Duration) {
+	index := anim.beforeUpdate(float64(elapsedTime)*anim.timeline.Speed() < 0)
+	anim.timeline.UpdateWithDelta(elapsedTime)
+	anim.frameChanged(index)
+}
+
+// beforeUpdate dispatches EventStart on the first update and keeps
+// the frame left by the update for the root motion. It returns the
+// index of the current frame.
+func (anim *Animation) beforeUpdate(backward bool) int {
+	anim.sync()
+	if !anim.started && anim.timeline.Status() == Playing {
+		anim.started = true
+		anim.dispatch(EventStart, 0)
+		anim.startMotion()
+	}
+	index := anim.timeline.Index()
+	if anim.motion != nil {
+		anim.motionIndex = index
+		anim.backward = backward
+	}
+	return index
+}
+
+// frameChanged dispatches EventFrameChanged if the animation is no
+// longer on the frame at the index.
+func (anim *Animation) frameChanged(index int) {
+	if anim.timeline.Index() != index {
+		anim.dispatch(EventFrameChanged, 0)
 	}
-	anim.position = seekFrameIndex(anim.intervals, anim.timer)
 }
 
-// SetDurations sets the durations of the animation.
+// SetDurations sets the durations of the animation and rewinds it
+// to its first frame. In tick mode, numbers are counts of ticks.
 func (anim *Animation) SetDurations(durations interface{}) {
-	_durations := parseDurations(durations, anim.sprite.length)
-	anim.durations = _durations
-	anim.intervals, anim.totalDuration = parseIntervals(_durations)
-	anim.timer = 0
+	index := anim.timeline.Index()
+	if tick := anim.timeline.Tick(); tick != 0 {
+		anim.timeline.SetDurations(parseTicks(durations, anim.sprite.length, tick))
+	} else {
+		anim.timeline.SetDurations(parseDurations(durations, anim.sprite.length))
+	}
+	anim.frameChanged(index)
+}
+
+// sync updates the animation to the animation of the loader it was
+// created from if the file has been loaded again since, keeping the
+// current frame and the time elapsed in it where possible. The
+// direction and the repeat count follow the file unless they were
+// changed.
+func (anim *Animation) sync() {
+	l := anim.loaded
+	if l == nil || anim.synced.frames == l.frames {
+		return
+	}
+	old := anim.synced
+	anim.synced = *l
+	index := anim.timeline.Index()
+	anim.sprite = l.sprite
+	frames := l.frames
+	if direction := anim.timeline.Direction(); direction != old.frames.Direction() {
+		frames = frames.WithDirection(direction)
+	}
+	anim.timeline.ReplaceDef(frames)
+	if anim.timeline.Repeat() == old.repeat && l.repeat != old.repeat {
+		anim.timeline.SetRepeat(l.repeat)
+	}
+	anim.frameChanged(index)
+}
+
+// frame returns the index of the frame to draw.
+func (anim *Animation) frame() int {
+	anim.sync()
+	return anim.timeline.Index()
 }
 
 // Status returns the status of the animation.
 func (anim *Animation) Status() Status {
-	return anim.status
+	return anim.timeline.Status()
 }
 
-// Pause pauses the animation.
+// Pause pauses the animation, or its whole group.
 func (anim *Animation) Pause() {
-	anim.status = Paused
+	if anim.group != nil {
+		anim.group.Pause()
+		return
+	}
+	anim.pause()
+}
+
+func (anim *Animation) pause() {
+	playing := anim.timeline.Status() == Playing
+	anim.timeline.Pause()
+	if playing {
+		anim.dispatch(EventPause, 0)
+	}
 }
 
 // Position returns the current position of the frame.
 // The position counts from 1 (not 0).
 func (anim *Animation) Position() int {
-	return anim.position + 1
+	return anim.timeline.Position()
 }
 
 // Duration returns the current durations of each frames.
 func (anim *Animation) Durations() []time.Duration {
-	return anim.durations
+	return anim.timeline.Durations()
 }
 
 // TotalDuration returns the total duration of the animation.
 func (anim *Animation) TotalDuration() time.Duration {
-	return anim.totalDuration
+	return anim.timeline.TotalDuration()
 }
 
 // Size returns the size of the current frame.
@@ -280,7 +619,7 @@ func (anim *Animation) H() int {
 
 // Timer returns the current accumulated times of current frame.
 func (anim *Animation) Timer() time.Duration {
-	return anim.timer
+	return anim.timeline.Timer()
 }
 
 // Sprite returns the sprite of the animation.
@@ -288,40 +627,95 @@ func (anim *Animation) Sprite() *Sprite {
 	return anim.sprite
 }
 
+// Timeline returns the timeline driving the animation.
+func (anim *Animation) Timeline() *timeline.Timeline {
+	return anim.timeline
+}
+
 // GoToFrame sets the position of the animation and
 // sets the timer at the start of the frame.
 func (anim *Animation) GoToFrame(position int) {
-	anim.position = position - 1
-	anim.timer = anim.intervals[anim.position]
+	index := anim.timeline.Index()
+	anim.timeline.GoToFrame(position)
+	anim.frameChanged(index)
+}
+
+// SetTime moves the animation to the time in its cycle, clamped
+// between zero and the time it takes to play all the frames once.
+func (anim *Animation) SetTime(t time.Duration) {
+	index := anim.timeline.Index()
+	anim.timeline.SetTime(t)
+	anim.frameChanged(index)
+}
+
+// Progress returns how much of the cycle of the animation has
+// been played, from 0 to 1.
+func (anim *Animation) Progress() float64 {
+	return anim.timeline.Progress()
+}
+
+// SetProgress moves the animation to the progress of its cycle,
+// from 0 to 1.
+func (anim *Animation) SetProgress(p float64) {
+	anim.SetTime(time.Duration(p * float64(anim.timeline.Cycle())))
+}
+
+// RemainingFrameTime returns the time left before the animation
+// leaves the current frame.
+func (anim *Animation) RemainingFrameTime() time.Duration {
+	return anim.timeline.RemainingFrameTime()
 }
 
 // PauseAtEnd pauses the animation and set the position
 // to the last frame.
 func (anim *Animation) PauseAtEnd() {
-	anim.position = anim.sprite.length - 1
-	anim.timer = anim.totalDuration
-	anim.Pause()
+	index, playing := anim.timeline.Index(), anim.timeline.Status() == Playing
+	anim.timeline.PauseAtEnd()
+	anim.dispatchPause(index, playing)
 }
 
 // PauseAtStart pauses the animation and set the position
 // to the first frame.
 func (anim *Animation) PauseAtStart() {
-	anim.position = 0
-	anim.timer = 0
-	anim.status = Paused
+	index, playing := anim.timeline.Index(), anim.timeline.Status() == Playing
+	anim.timeline.PauseAtStart()
+	anim.dispatchPause(index, playing)
 }
 
-// Resume resumes the animation
+// dispatchPause dispatches the events of the animation moved from
+// the frame at the index and paused.
+func (anim *Animation) dispatchPause(index int, playing bool) {
+	anim.frameChanged(index)
+	if playing {
+		anim.dispatch(EventPause, 0)
+	}
+}
+
+// Resume resumes the animation, or its whole group.
 func (anim *Animation) Resume() {
-	anim.status = Playing
+	if anim.group != nil {
+		anim.group.Resume()
+		return
+	}
+	anim.resume()
+}
+
+func (anim *Animation) resume() {
+	paused := anim.timeline.Status() == Paused
+	anim.timeline.Resume()
+	if paused {
+		anim.dispatch(EventResume, 0)
+	}
 }
 
-// Draw draws the animation with the specified option parameters.
+// Draw draws the animation with the specified option parameters
+// modified by the tracks of the animation.
 func (anim *Animation) Draw(screen *ebiten.Image, opts *DrawOptions) {
-	anim.sprite.Draw(screen, anim.position, opts)
+	anim.sprite.Draw(screen, anim.frame(), anim.TrackOptions(opts))
 }
 
-// DrawWithShader draws the animation with the specified option parameters.
+// DrawWithShader draws the animation with the specified option parameters
+// modified by the tracks of the animation.
 func (anim *Animation) DrawWithShader(screen *ebiten.Image, opts *DrawOptions, shaderOpts *ShaderOptions) {
-	anim.sprite.DrawWithShader(screen, anim.position, opts, shaderOpts)
+	anim.sprite.DrawWithShader(screen, anim.frame(), anim.TrackOptions(opts), shaderOpts)
 }
@@ -323,3 +323,135 @@ func TestPauseAtStart(t *testing.T) {
 		})
 	}
 }
+
+func TestSpeed(t *testing.T) {
+	d := func(s time.Duration) time.Duration {
+		return s * time.Second
+	}
+	var tests = []struct {
+		name  string
+		speed float64
+		delta time.Duration
+		want  int
+	}{
+		{"plays at double speed", 2, d(1), 3},
+		{"plays at half speed", 0.5, d(2), 2},
+		{"freezes at zero speed", 0, d(2), 1},
+		{"plays backward at negative speed", -1, d(1), 4},
+		{"plays backward across the start", -1, d(6), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anim := ganim8.NewAnimation(mockSprite(4), d(1), ganim8.Nop)
+			anim.SetSpeed(tt.speed)
+			anim.UpdateWithDelta(tt.delta)
+			got := anim.Position()
+			if got != tt.want {
+				t.Errorf("%s: got %v; want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeScale(t *testing.T) {
+	defer func(s float64) { ganim8.TimeScale = s }(ganim8.TimeScale)
+	anim := ganim8.NewAnimation(mockSprite(4), ganim8.DefaultDelta*2, ganim8.Nop)
+	ganim8.TimeScale = 0.5
+	anim.Update()
+	anim.Update()
+	anim.Update()
+	if got := anim.Position(); got != 1 {
+		t.Errorf("got %v; want %v", got, 1)
+	}
+	anim.Update()
+	if got := anim.Position(); got != 2 {
+		t.Errorf("got %v; want %v", got, 2)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	d := func(s time.Duration) time.Duration {
+		return s * time.Second
+	}
+	anim := ganim8.NewAnimation(mockSprite(4), d(1), ganim8.Nop)
+	anim.AddEvent("footstep", "2-3")
+	got := []string{}
+	anim.SetOnEvent(func(a *ganim8.Animation, event string, position int) {
+		got = append(got, fmt.Sprintf("%s:%d", event, position))
+	})
+	clone := anim.Clone()
+	clone.AddEvent("hit", 4)
+	anim.UpdateWithDelta(d(7))
+	want := "[footstep:2 footstep:3 footstep:2 footstep:3]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if len(anim.Events(4)) != 0 {
+		t.Errorf("events of the clone are shared: %v", anim.Events(4))
+	}
+}
+
+func TestSetProgress(t *testing.T) {
+	anim := ganim8.NewAnimation(mockSprite(4), time.Second, ganim8.Nop)
+	changed := 0
+	anim.Subscribe(ganim8.EventFrameChanged, func(a *ganim8.Animation, e ganim8.Event) {
+		changed++
+	})
+	anim.SetProgress(0.6)
+	if got := anim.Position(); got != 3 {
+		t.Errorf("got %v; want %v", got, 3)
+	}
+	if got := anim.RemainingFrameTime(); got != time.Millisecond*600 {
+		t.Errorf("got %v; want %v", got, time.Millisecond*600)
+	}
+	if changed != 1 {
+		t.Errorf("got %v frame changes; want %v", changed, 1)
+	}
+}
+
+func TestTickAnimation(t *testing.T) {
+	anim := ganim8.NewTickAnimation(mockSprite(4), map[string]int{"1": 2, "2-4": 1}, ganim8.Nop)
+	want := []int{1, 1, 2, 3, 4, 1, 1}
+	for i, w := range want {
+		if got := anim.Position(); got != w {
+			t.Errorf("at tick %d: got %v; want %v", i, got, w)
+		}
+		anim.Update()
+	}
+
+	anim.SetDurations(3)
+	anim.UpdateTicks(5)
+	if got := anim.Position(); got != 2 {
+		t.Errorf("got %v; want %v", got, 2)
+	}
+	if got := anim.TickTimer(); got != 5 {
+		t.Errorf("got %v; want %v", got, 5)
+	}
+}
+
+func TestSingleFrameAnimation(t *testing.T) {
+	var tests = []struct {
+		name          string
+		repeat        int
+		delta         time.Duration
+		wantLoops     int
+		wantCompleted bool
+	}{
+		{"loops every duration of its frame", 0, time.Second * 2, 2, false},
+		{"completes after its repeat count", 2, time.Second * 5, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loops, completed := 0, false
+			anim := ganim8.NewAnimation(mockSprite(1), time.Second, func(anim *ganim8.Animation, n int) {
+				loops += n
+			})
+			anim.SetOnComplete(func(anim *ganim8.Animation) { completed = true })
+			anim.SetRepeat(tt.repeat)
+			anim.UpdateWithDelta(tt.delta)
+			if loops != tt.wantLoops || completed != tt.wantCompleted {
+				t.Errorf("%s: got %v loops, completed %v; want %v, %v", tt.name, loops, completed, tt.wantLoops, tt.wantCompleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package ganim8
+
+import (
+	"log"
+	"time"
+
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+// AnimationDef is the immutable definition of an animation: its
+// sprite, the durations of the frames and metadata (a name, a
+// direction and a repeat count).
+//
+// A definition is meant to be shared by every animation and player
+// of the same kind, e.g. the walk cycle of thousands of units, so
+// that they don't each copy the durations.
+//
+// The definitions of a Loader follow their file when it is loaded
+// again: they get the new durations, and the new direction and
+// repeat count unless they were changed with WithDirection and
+// WithRepeat.
+type AnimationDef struct {
+	name   string
+	sprite *Sprite
+	frames *timeline.Def
+	repeat int
+
+	loaded *loadedAnimation
+	synced loadedAnimation
+}
+
+// NewAnimationDef returns a new definition of an animation of the
+// sprite. durations are the same as the durations of NewAnimation.
+func NewAnimationDef(name string, sprite *Sprite, durations interface{}) *AnimationDef {
+	frames := timeline.NewDef(parseDurations(durations, sprite.length), Forward)
+	return &AnimationDef{name: name, sprite: sprite, frames: frames}
+}
+
+// WithDirection returns a copy of the definition whose frames are
+// played in the direction.
+func (def *AnimationDef) WithDirection(direction Direction) *AnimationDef {
+	def.sync()
+	new := *def
+	new.frames = def.frames.WithDirection(direction)
+	return &new
+}
+
+// WithRepeat returns a copy of the definition played the number of
+// times before it completes (see Animation.SetRepeat).
+func (def *AnimationDef) WithRepeat(count int) *AnimationDef {
+	if count < 0 {
+		log.Fatalf("repeat count of animation %s is negative", def.name)
+	}
+	def.sync()
+	new := *def
+	new.repeat = count
+	return &new
+}
+
+// sync updates the definition to the animation of the loader it
+// was created from if the file has been loaded again since.
+func (def *AnimationDef) sync() {
+	l := def.loaded
+	if l == nil || def.synced.frames == l.frames {
+		return
+	}
+	old := def.synced
+	def.synced = *l
+	def.sprite = l.sprite
+	if direction := def.frames.Direction(); direction != old.frames.Direction() {
+		def.frames = l.frames.WithDirection(direction)
+	} else {
+		def.frames = l.frames
+	}
+	if def.repeat == old.repeat {
+		def.repeat = l.repeat
+	}
+}
+
+// Name returns the name of the animations of the definition.
+func (def *AnimationDef) Name() string {
+	return def.name
+}
+
+// Sprite returns the sprite of the definition.
+func (def *AnimationDef) Sprite() *Sprite {
+	def.sync()
+	return def.sprite
+}
+
+// Durations returns the durations of each frames. They must not be
+// modified.
+func (def *AnimationDef) Durations() []time.Duration {
+	def.sync()
+	return def.frames.Durations()
+}
+
+// TotalDuration returns the total duration of the frames.
+func (def *AnimationDef) TotalDuration() time.Duration {
+	def.sync()
+	return def.frames.TotalDuration()
+}
+
+// Direction returns the direction in which the frames are played.
+func (def *AnimationDef) Direction() Direction {
+	def.sync()
+	return def.frames.Direction()
+}
+
+// Repeat returns how many times the animation is played before it
+// completes. Zero means forever.
+func (def *AnimationDef) Repeat() int {
+	def.sync()
+	return def.repeat
+}
+
+// Frames returns the timing of the frames, shared with the
+// timelines of the animations of the definition.
+func (def *AnimationDef) Frames() *timeline.Def {
+	def.sync()
+	return def.frames
+}
+
+// NewAnimationFromDef returns a new animation playing the
+// definition. The animation shares the sprite and the durations of
+// the definition and is named after it.
+func NewAnimationFromDef(def *AnimationDef, onLoop ...OnLoop) *Animation {
+	def.sync()
+	anim := newAnimation(def.sprite, timeline.NewFromDef(def.frames), onLoop)
+	anim.SetName(def.name)
+	anim.SetRepeat(def.repeat)
+	anim.loaded, anim.synced = def.loaded, def.synced
+	return anim
+}
+
+// Def returns the definition of the animation as it is now. It
+// shares the sprite and the durations of the animation.
+func (anim *Animation) Def() *AnimationDef {
+	anim.sync()
+	return &AnimationDef{
+		name:   anim.name,
+		sprite: anim.sprite,
+		frames: anim.timeline.Def(),
+		repeat: anim.timeline.Repeat(),
+		loaded: anim.loaded,
+		synced: anim.synced,
+	}
+}
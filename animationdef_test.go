@@ -0,0 +1,90 @@
+package ganim8_test
+
+import (
+	"image"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockAnimationDef() *ganim8.AnimationDef {
+	frame := image.Rect(0, 0, 1, 1)
+	spr := ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame, &frame})
+	return ganim8.NewAnimationDef("walk", spr, time.Second)
+}
+
+func TestAnimationDef(t *testing.T) {
+	def := mockAnimationDef().WithDirection(ganim8.Reverse).WithRepeat(2)
+	require.Equal(t, ganim8.Reverse, def.Direction())
+	require.Equal(t, 2, def.Repeat())
+
+	anim := ganim8.NewAnimationFromDef(def)
+	require.Equal(t, "walk", anim.Name())
+	require.Equal(t, 3, anim.Position())
+	require.Equal(t, 2, anim.Repeat())
+	require.Same(t, &def.Durations()[0], &anim.Durations()[0])
+
+	anim.SetDurations(time.Millisecond)
+	require.Equal(t, time.Second*3, def.TotalDuration())
+	require.Equal(t, time.Millisecond*3, anim.Def().TotalDuration())
+}
+
+func TestPlayer(t *testing.T) {
+	def := mockAnimationDef().WithRepeat(2)
+	p := ganim8.NewPlayer(def)
+	require.Same(t, def, p.Def())
+
+	require.Equal(t, 0, p.UpdateWithDelta(time.Second))
+	require.Equal(t, 2, p.Position())
+	require.Equal(t, 1, p.UpdateWithDelta(time.Second*3))
+	require.Equal(t, 2, p.Position())
+	require.Equal(t, 1, p.UpdateWithDelta(time.Second*100))
+	require.True(t, p.IsComplete())
+	require.True(t, p.IsEnd())
+	require.Equal(t, 0, p.UpdateWithDelta(time.Second))
+
+	p.Restart()
+	require.Equal(t, 1, p.Position())
+	require.True(t, p.Status() == ganim8.Playing)
+
+	players := make([]ganim8.Player, 100)
+	allocs := testing.AllocsPerRun(10, func() {
+		for i := range players {
+			players[i] = ganim8.NewPlayer(def)
+			players[i].UpdateWithDelta(time.Second * 2)
+		}
+	})
+	require.Zero(t, allocs)
+}
+
+func TestLoaderAnimationDef(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sheet.png": {Data: mockPNG(t, 32, 16)},
+		"sheet.json": {Data: []byte(`{
+			"image": "sheet.png",
+			"sprites": { "walk": { "grid": { "frameWidth": 16, "frameHeight": 16 }, "frames": ["1-2", 1] } },
+			"animations": { "walk": { "durations": 100, "direction": "reverse", "repeat": 3 } }
+		}`)},
+	}
+	loader := ganim8.NewLoader(fsys)
+	require.NoError(t, loader.Load("sheet.json"))
+
+	def, err := loader.AnimationDef("walk")
+	require.NoError(t, err)
+	def2, err := loader.AnimationDef("walk")
+	require.NoError(t, err)
+	require.Same(t, def, def2)
+	require.Equal(t, ganim8.Reverse, def.Direction())
+	require.Equal(t, 3, def.Repeat())
+
+	walk, err := loader.Animation("walk")
+	require.NoError(t, err)
+	require.Same(t, &def.Durations()[0], &walk.Durations()[0])
+	require.Equal(t, ganim8.Reverse, walk.Direction())
+
+	_, err = loader.AnimationDef("run")
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package ganim8
+
+import (
+	"log"
+	"math"
+)
+
+// Attachment is a named point of a frame, e.g. the hand holding a
+// weapon, with its angle in radians.
+type Attachment struct {
+	X, Y  float64
+	Angle float64
+}
+
+// SetAttachment sets the attachment point of the frame at the index
+// in the local coordinates of the frame (pixels from its top left
+// corner).
+func (spr *Sprite) SetAttachment(name string, index int, a Attachment) {
+	if index < 0 || index >= spr.length {
+		log.Fatalf("attachment %s is set on frame %d of a sprite of %d frames",
+			name, index+1, spr.length)
+	}
+	if spr.attachments == nil {
+		spr.attachments = map[string]map[int]Attachment{}
+	}
+	if spr.attachments[name] == nil {
+		spr.attachments[name] = map[int]Attachment{}
+	}
+	spr.attachments[name][index] = a
+}
+
+// RemoveAttachment removes the attachment point from every frame.
+func (spr *Sprite) RemoveAttachment(name string) {
+	delete(spr.attachments, name)
+}
+
+// LocalAttachment returns the attachment point of the frame at the
+// index in the local coordinates of the frame, and false if the
+// frame has no such point.
+func (spr *Sprite) LocalAttachment(name string, index int) (Attachment, bool) {
+	a, ok := spr.attachments[name][index]
+	return a, ok
+}
+
+// Attachment returns the attachment point of the frame at the index
+// transformed like the frame is when it is drawn with the options:
+// the position is on the screen and the angle accounts for the
+// rotation, the scale and the flips of the sprite. It returns false
+// if the frame has no such point.
+func (spr *Sprite) Attachment(name string, index int, opts *DrawOptions) (Attachment, bool) {
+	a, ok := spr.LocalAttachment(name, index)
+	if !ok {
+		return Attachment{}, false
+	}
+	g := spr.GeoM(opts)
+	x, y := g.Apply(a.X, a.Y)
+	dx, dy := g.Apply(a.X+math.Cos(a.Angle), a.Y+math.Sin(a.Angle))
+	return Attachment{X: x, Y: y, Angle: math.Atan2(dy-y, dx-x)}, true
+}
+
+// Attachment returns the attachment point of the current frame
+// transformed like the frame is when the animation is drawn with
+// the options, tracks included.
+func (anim *Animation) Attachment(name string, opts *DrawOptions) (Attachment, bool) {
+	return anim.sprite.Attachment(name, anim.frame(), anim.TrackOptions(opts))
+}
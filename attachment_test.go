@@ -0,0 +1,44 @@
+package ganim8_test
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestAttachment(t *testing.T) {
+	var tests = []struct {
+		name  string
+		opts  *ganim8.DrawOptions
+		flipH bool
+		want  ganim8.Attachment
+	}{
+		{"moves with the position", ganim8.DrawOpts(10, 10), false, ganim8.Attachment{X: 13, Y: 11}},
+		{"flips horizontally", ganim8.DrawOpts(10, 10), true, ganim8.Attachment{X: 11, Y: 11, Angle: math.Pi}},
+		{"rotates", ganim8.DrawOpts(10, 10, math.Pi/2), false, ganim8.Attachment{X: 9, Y: 13, Angle: math.Pi / 2}},
+		{"scales around the origin", ganim8.DrawOpts(10, 10, 0, 2, 2, 0.5, 0.5), false, ganim8.Attachment{X: 12, Y: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := image.Rect(0, 0, 4, 2)
+			spr := ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame})
+			spr.SetAttachment("hand", 1, ganim8.Attachment{X: 3, Y: 1})
+			spr.SetFlipH(tt.flipH)
+			anim := ganim8.NewAnimation(spr, time.Second)
+
+			_, ok := anim.Attachment("hand", tt.opts)
+			require.False(t, ok)
+
+			anim.UpdateWithDelta(time.Second)
+			a, ok := anim.Attachment("hand", tt.opts)
+			require.True(t, ok)
+			require.InDelta(t, tt.want.X, a.X, 1e-9)
+			require.InDelta(t, tt.want.Y, a.Y, 1e-9)
+			require.InDelta(t, tt.want.Angle, a.Angle, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package ganim8
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Layer is a layer of a composite: a sprite drawn with the frames
+// of the composite timing.
+type Layer struct {
+	Name   string
+	Sprite *Sprite
+	// OffsetX and OffsetY move the layer in the local coordinates of
+	// the frames, so the offset is scaled, rotated and flipped with
+	// the composite.
+	OffsetX, OffsetY float64
+	// Tint is applied to the layer before the color matrix of the
+	// draw options.
+	Tint    ebiten.ColorM
+	Visible bool
+}
+
+// Composite draws layers of sprites laid out on identical grids
+// (body, armor, weapon...) with the timing of one animation, like
+// a paper doll.
+type Composite struct {
+	anim   *Animation
+	layers []*Layer
+	flipH  bool
+	flipV  bool
+	opts   DrawOptions
+}
+
+// NewComposite returns a new composite without layers whose frames
+// follow the animation.
+func NewComposite(anim *Animation) *Composite {
+	return &Composite{anim: anim}
+}
+
+// Animation returns the animation that the layers follow.
+func (c *Composite) Animation() *Animation {
+	return c.anim
+}
+
+// AddLayer adds a visible layer on top of the others and returns it.
+// The sprite needs to have as many frames as the animation.
+func (c *Composite) AddLayer(name string, spr *Sprite) *Layer {
+	c.checkSprite(name, spr)
+	l := &Layer{Name: name, Sprite: spr, Visible: true}
+	c.layers = append(c.layers, l)
+	return l
+}
+
+// Layer returns the layer with the name, or nil.
+func (c *Composite) Layer(name string) *Layer {
+	for _, l := range c.layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Layers returns the layers from the bottom to the top.
+func (c *Composite) Layers() []*Layer {
+	return c.layers
+}
+
+// RemoveLayer removes the layer with the name.
+func (c *Composite) RemoveLayer(name string) {
+	for i, l := range c.layers {
+		if l.Name == name {
+			layers := make([]*Layer, 0, len(c.layers)-1)
+			layers = append(layers, c.layers[:i]...)
+			c.layers = append(layers, c.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetSprite swaps the sprite of the layer, e.g. to change armor.
+func (c *Composite) SetSprite(name string, spr *Sprite) {
+	l := c.mustLayer(name)
+	c.checkSprite(name, spr)
+	l.Sprite = spr
+}
+
+// SetVisible shows or hides the layer.
+func (c *Composite) SetVisible(name string, visible bool) {
+	c.mustLayer(name).Visible = visible
+}
+
+// SetFlipH flips every layer horizontally, offsets included, and
+// mirrors the root motion of the animation.
+func (c *Composite) SetFlipH(flipH bool) {
+	c.flipH = flipH
+	c.anim.flipH = flipH
+}
+
+// SetFlipV flips every layer vertically, offsets included, and
+// mirrors the root motion of the animation.
+func (c *Composite) SetFlipV(flipV bool) {
+	c.flipV = flipV
+	c.anim.flipV = flipV
+}
+
+func (c *Composite) mustLayer(name string) *Layer {
+	l := c.Layer(name)
+	if l == nil {
+		log.Fatalf("layer %s is not added", name)
+	}
+	return l
+}
+
+func (c *Composite) checkSprite(name string, spr *Sprite) {
+	if spr.Length() < c.anim.timeline.Length() {
+		log.Fatalf("layer %s has %d frames for an animation of %d frames",
+			name, spr.Length(), c.anim.timeline.Length())
+	}
+}
+
+// Update updates the animation of the composite.
+func (c *Composite) Update() {
+	c.anim.Update()
+}
+
+// UpdateWithDelta updates the animation of the composite with the
+// delta.
+func (c *Composite) UpdateWithDelta(elapsedTime time.Duration) {
+	c.anim.UpdateWithDelta(elapsedTime)
+}
+
+// Draw draws the visible layers from the bottom to the top with
+// the options modified by the tracks of the animation.
+func (c *Composite) Draw(screen *ebiten.Image, opts *DrawOptions) {
+	c.draw(opts, func(spr *Sprite, index int, opts *DrawOptions) {
+		spr.Draw(screen, index, opts)
+	})
+}
+
+// Render draws the visible layers from the bottom to the top with
+// the renderer.
+func (c *Composite) Render(r Renderer, opts *DrawOptions) {
+	c.draw(opts, func(spr *Sprite, index int, opts *DrawOptions) {
+		spr.Render(r, index, opts)
+	})
+}
+
+func (c *Composite) draw(opts *DrawOptions, draw func(spr *Sprite, index int, opts *DrawOptions)) {
+	index := c.anim.frame()
+	opts = c.anim.TrackOptions(opts)
+	for _, l := range c.layers {
+		if !l.Visible {
+			continue
+		}
+		spr := l.Sprite
+		c.opts = *opts
+		c.opts.ColorM = l.Tint
+		c.opts.ColorM.Concat(opts.ColorM)
+		// the flip of the composite is a negative scale around the
+		// mirrored origin, the same as the flip of a sprite, so it
+		// combines with the flip of the layer sprite
+		if c.flipH {
+			c.opts.ScaleX, c.opts.OriginX = -c.opts.ScaleX, 1-c.opts.OriginX
+		}
+		if c.flipV {
+			c.opts.ScaleY, c.opts.OriginY = -c.opts.ScaleY, 1-c.opts.OriginY
+		}
+		dx, dy := l.OffsetX*c.opts.ScaleX, l.OffsetY*c.opts.ScaleY
+		if spr.flippedH {
+			dx = -dx
+		}
+		if spr.flippedV {
+			dy = -dy
+		}
+		sin, cos := math.Sincos(opts.Rotate)
+		c.opts.X += dx*cos - dy*sin
+		c.opts.Y += dx*sin + dy*cos
+		draw(spr, index, &c.opts)
+	}
+}
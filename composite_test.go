@@ -0,0 +1,107 @@
+package ganim8_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+// mockLayerSprite returns a sprite of two 1x1 frames of the color.
+func mockLayerSprite(c color.Color) *ganim8.Sprite {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, c)
+	src.Set(1, 0, c)
+	r1, r2 := image.Rect(0, 0, 1, 1), image.Rect(1, 0, 2, 1)
+	return ganim8.NewSpriteFromImage(src, []*image.Rectangle{&r1, &r2})
+}
+
+func TestComposite(t *testing.T) {
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	var tests = []struct {
+		name  string
+		setup func(c *ganim8.Composite)
+		opts  *ganim8.DrawOptions
+		want  map[image.Point]color.RGBA
+	}{
+		{"draws the layers in order", func(c *ganim8.Composite) {}, ganim8.DrawOpts(1, 1),
+			map[image.Point]color.RGBA{{1, 1}: green, {3, 1}: blue}},
+		{"hides layers", func(c *ganim8.Composite) { c.SetVisible("armor", false) }, ganim8.DrawOpts(1, 1),
+			map[image.Point]color.RGBA{{1, 1}: red, {3, 1}: blue}},
+		{"swaps layers", func(c *ganim8.Composite) { c.SetSprite("armor", mockLayerSprite(blue)) }, ganim8.DrawOpts(1, 1),
+			map[image.Point]color.RGBA{{1, 1}: blue, {3, 1}: blue}},
+		{"scales the offsets", func(c *ganim8.Composite) {}, ganim8.DrawOpts(0, 0, 0, 2, 2),
+			map[image.Point]color.RGBA{{0, 0}: green, {4, 0}: blue}},
+		{"flips the offsets", func(c *ganim8.Composite) { c.SetFlipH(true) }, ganim8.DrawOpts(3, 1),
+			map[image.Point]color.RGBA{{3, 1}: green, {1, 1}: blue}},
+		{"tints the layers", func(c *ganim8.Composite) { c.Layer("weapon").Tint.Scale(0, 0, 1, 0.5) }, ganim8.DrawOpts(1, 1),
+			map[image.Point]color.RGBA{{1, 1}: green, {3, 1}: {0, 0, 0x7f, 0x7f}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anim := ganim8.NewAnimation(mockLayerSprite(red), time.Second)
+			c := ganim8.NewComposite(anim)
+			c.AddLayer("body", mockLayerSprite(red))
+			c.AddLayer("armor", mockLayerSprite(green))
+			c.AddLayer("weapon", mockLayerSprite(blue)).OffsetX = 2
+			tt.setup(c)
+			c.UpdateWithDelta(time.Second)
+
+			dst := image.NewRGBA(image.Rect(0, 0, 8, 8))
+			c.Render(ganim8.NewCPURenderer(dst), tt.opts)
+			for p, want := range tt.want {
+				require.Equal(t, want, dst.At(p.X, p.Y), "at %v", p)
+			}
+		})
+	}
+}
+
+// geoMRenderer records the matrix of the sprites drawn without
+// options, which only depends on the flips of the sprites.
+type geoMRenderer []ebiten.GeoM
+
+func (r *geoMRenderer) DrawFrame(spr *ganim8.Sprite, index int, geoM ebiten.GeoM, opts *ganim8.DrawOptions) {
+	*r = append(*r, spr.GeoM(ganim8.DrawOpts(0, 0)))
+}
+
+func TestCompositeFlipKeepsSprites(t *testing.T) {
+	spr := mockLayerSprite(red)
+	c := ganim8.NewComposite(ganim8.NewAnimation(mockLayerSprite(red), time.Second))
+	c.AddLayer("body", spr)
+	c.SetFlipH(true)
+
+	var r geoMRenderer
+	c.Render(&r, ganim8.DrawOpts(0, 0))
+	require.Equal(t, geoMRenderer{mockLayerSprite(red).GeoM(ganim8.DrawOpts(0, 0))}, r)
+}
+
+func TestCompositeFlipMirrorsRootMotion(t *testing.T) {
+	anim := ganim8.NewAnimation(mockLayerSprite(red), time.Second)
+	anim.SetRootMotion("1-2", 2, 1)
+	c := ganim8.NewComposite(anim)
+	c.SetFlipH(true)
+	anim.UpdateWithDelta(time.Second)
+	dx, dy := anim.TakeRootMotion()
+	require.Equal(t, []float64{-4, 2}, []float64{dx, dy})
+
+	// the flip of the sprite cancels the one of the composite
+	anim.Sprite().SetFlipH(true)
+	anim.UpdateWithDelta(time.Second)
+	dx, _ = anim.TakeRootMotion()
+	require.Equal(t, 2.0, dx)
+}
+
+func TestCompositeRemoveLayer(t *testing.T) {
+	c := ganim8.NewComposite(ganim8.NewAnimation(mockLayerSprite(red), time.Second))
+	c.AddLayer("body", mockLayerSprite(red))
+	c.AddLayer("cape", mockLayerSprite(green))
+	layers := c.Layers()
+	c.RemoveLayer("body")
+	require.Equal(t, []string{"body", "cape"}, []string{layers[0].Name, layers[1].Name})
+	require.Len(t, c.Layers(), 1)
+	require.Equal(t, "cape", c.Layers()[0].Name)
+}
@@ -0,0 +1,159 @@
+package ganim8
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AlphaUniform is the name of the float uniform set to the alpha
+// of each animation when a crossfade is drawn with a shader. The
+// shader is expected to multiply its color by it.
+var AlphaUniform = "Alpha"
+
+// Crossfade blends an outgoing animation into an incoming one over
+// a duration, drawing the outgoing animation with a shrinking alpha
+// and the incoming one over it with a growing alpha.
+type Crossfade struct {
+	from     *Animation
+	to       *Animation
+	duration time.Duration
+	elapsed  time.Duration
+	opaque   bool
+
+	opts       DrawOptions
+	shaderOpts ShaderOptions
+	uniforms   map[string]interface{}
+}
+
+// NewCrossfade returns a new crossfade from an animation to another
+// lasting the duration.
+func NewCrossfade(from, to *Animation, duration time.Duration) *Crossfade {
+	return &Crossfade{from: from, to: to, duration: duration}
+}
+
+// From returns the outgoing animation.
+func (c *Crossfade) From() *Animation {
+	return c.from
+}
+
+// To returns the incoming animation.
+func (c *Crossfade) To() *Animation {
+	return c.to
+}
+
+// SetOpaqueFrom sets whether the outgoing animation is drawn
+// opaque under the incoming one instead of fading out, so that the
+// blend never gets more transparent than the animations where they
+// overlap.
+func (c *Crossfade) SetOpaqueFrom(opaque bool) {
+	c.opaque = opaque
+}
+
+// Alpha returns the alpha of the incoming animation, from 0 to 1.
+func (c *Crossfade) Alpha() float64 {
+	if c.duration <= 0 || c.elapsed >= c.duration {
+		return 1
+	}
+	return float64(c.elapsed) / float64(c.duration)
+}
+
+// IsDone returns true once the blend is finished and only the
+// incoming animation is drawn.
+func (c *Crossfade) IsDone() bool {
+	return c.elapsed >= c.duration
+}
+
+// Update updates the crossfade with the delta of Animation.Update
+// of the incoming animation.
+func (c *Crossfade) Update() {
+	c.UpdateWithDelta(c.to.updateDelta())
+}
+
+// UpdateWithDelta advances the blend and updates both animations
+// with the delta, or only the incoming one once the blend is done.
+func (c *Crossfade) UpdateWithDelta(elapsedTime time.Duration) {
+	if !c.IsDone() {
+		c.from.UpdateWithDelta(elapsedTime)
+		c.elapsed += elapsedTime
+		if c.elapsed > c.duration {
+			c.elapsed = c.duration
+		}
+	}
+	c.to.UpdateWithDelta(elapsedTime)
+}
+
+// Draw draws the outgoing animation and the incoming one over it
+// with their alphas applied to the color matrix of the options.
+func (c *Crossfade) Draw(screen *ebiten.Image, opts *DrawOptions) {
+	c.draw(opts, func(anim *Animation, opts *DrawOptions) {
+		anim.Draw(screen, opts)
+	})
+}
+
+// Render draws the outgoing animation and the incoming one over it
+// with the renderer, like Draw.
+func (c *Crossfade) Render(r Renderer, opts *DrawOptions) {
+	c.draw(opts, func(anim *Animation, opts *DrawOptions) {
+		anim.Render(r, opts)
+	})
+}
+
+// fromAlpha returns the alpha of the outgoing animation.
+func (c *Crossfade) fromAlpha() float64 {
+	if c.opaque {
+		return 1
+	}
+	return 1 - c.Alpha()
+}
+
+func (c *Crossfade) draw(opts *DrawOptions, draw func(anim *Animation, opts *DrawOptions)) {
+	alpha := c.Alpha()
+	if alpha >= 1 {
+		draw(c.to, opts)
+		return
+	}
+	if alpha <= 0 {
+		draw(c.from, opts)
+		return
+	}
+	c.opts = *opts
+	c.opts.ColorM.Scale(1, 1, 1, c.fromAlpha())
+	draw(c.from, &c.opts)
+	c.opts = *opts
+	c.opts.ColorM.Scale(1, 1, 1, alpha)
+	draw(c.to, &c.opts)
+}
+
+// DrawWithShader draws both animations with the shader like Draw,
+// setting the AlphaUniform uniform to the alpha of each animation.
+// The uniforms of the options are copied and not modified.
+func (c *Crossfade) DrawWithShader(screen *ebiten.Image, opts *DrawOptions, shaderOpts *ShaderOptions) {
+	alpha := c.Alpha()
+	if alpha >= 1 {
+		c.drawWithShader(screen, c.to, 1, opts, shaderOpts)
+		return
+	}
+	if alpha <= 0 {
+		c.drawWithShader(screen, c.from, 1, opts, shaderOpts)
+		return
+	}
+	c.drawWithShader(screen, c.from, c.fromAlpha(), opts, shaderOpts)
+	c.drawWithShader(screen, c.to, alpha, opts, shaderOpts)
+}
+
+func (c *Crossfade) drawWithShader(screen *ebiten.Image, anim *Animation, alpha float64, opts *DrawOptions, shaderOpts *ShaderOptions) {
+	if c.uniforms == nil {
+		c.uniforms = map[string]interface{}{}
+	}
+	for key := range c.uniforms {
+		delete(c.uniforms, key)
+	}
+	for key, value := range shaderOpts.Uniforms {
+		c.uniforms[key] = value
+	}
+	c.uniforms[AlphaUniform] = float32(alpha)
+	c.shaderOpts = *shaderOpts
+	c.shaderOpts.Uniforms = c.uniforms
+	anim.DrawWithShader(screen, opts, &c.shaderOpts)
+}
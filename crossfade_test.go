@@ -0,0 +1,72 @@
+package ganim8_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+// mockPixelAnimation returns an animation of the pixel x of the
+// sprite of mockSourceSprite (0 is red and 1 is green).
+func mockPixelAnimation(x int) *ganim8.Animation {
+	r := image.Rect(x, 0, x+1, 1)
+	spr := ganim8.NewSpriteFromImage(mockSourceSprite().Source(), []*image.Rectangle{&r})
+	return ganim8.NewAnimation(spr, time.Second)
+}
+
+func TestCrossfade(t *testing.T) {
+	var tests = []struct {
+		name   string
+		delta  time.Duration
+		opaque bool
+		alpha  float64
+		want   color.RGBA
+	}{
+		{"draws only the outgoing animation at the start", 0, false, 0, color.RGBA{0xff, 0, 0, 0xff}},
+		{"blends both animations", time.Millisecond * 500, false, 0.5, color.RGBA{0x40, 0x7f, 0, 0xbf}},
+		{"blends over the opaque outgoing animation", time.Millisecond * 500, true, 0.5, color.RGBA{0x80, 0x7f, 0, 0xff}},
+		{"draws only the incoming animation at the end", time.Second * 2, false, 1, color.RGBA{0, 0xff, 0, 0xff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ganim8.NewCrossfade(mockPixelAnimation(0), mockPixelAnimation(1), time.Second)
+			c.SetOpaqueFrom(tt.opaque)
+			c.UpdateWithDelta(tt.delta)
+			require.InDelta(t, tt.alpha, c.Alpha(), 1e-9)
+			require.Equal(t, tt.alpha == 1, c.IsDone())
+
+			dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+			opts := ganim8.DrawOpts(0, 0)
+			c.Render(ganim8.NewCPURenderer(dst), opts)
+			require.Equal(t, tt.want, dst.At(0, 0))
+			require.Equal(t, ganim8.DrawOpts(0, 0), opts)
+		})
+	}
+}
+
+// alphaRenderer records the alpha scale of the color matrix of the
+// frames drawn.
+type alphaRenderer []float64
+
+func (r *alphaRenderer) DrawFrame(spr *ganim8.Sprite, index int, geoM ebiten.GeoM, opts *ganim8.DrawOptions) {
+	*r = append(*r, opts.ColorM.Element(3, 3))
+}
+
+func TestCrossfadeAlphas(t *testing.T) {
+	c := ganim8.NewCrossfade(mockPixelAnimation(0), mockPixelAnimation(1), time.Second)
+	c.UpdateWithDelta(time.Millisecond * 250)
+
+	var r alphaRenderer
+	c.Render(&r, ganim8.DrawOpts(0, 0))
+	require.InDeltaSlice(t, []float64{0.75, 0.25}, []float64(r), 1e-6)
+
+	r = nil
+	c.SetOpaqueFrom(true)
+	c.Render(&r, ganim8.DrawOpts(0, 0))
+	require.InDeltaSlice(t, []float64{1, 0.25}, []float64(r), 1e-6)
+}
@@ -0,0 +1,94 @@
+package ganim8
+
+// EventType represents the kind of an event of an animation.
+type EventType int
+
+const (
+	// EventStart is dispatched when the animation starts playing:
+	// on its first update and when it is restarted.
+	EventStart EventType = iota
+	// EventFrameChanged is dispatched when the frame shown by the
+	// animation changes.
+	EventFrameChanged
+	// EventLoop is dispatched when the animation loops.
+	EventLoop
+	// EventComplete is dispatched when the animation has played as
+	// many times as its repeat count.
+	EventComplete
+	// EventPause is dispatched when the animation is paused.
+	EventPause
+	// EventResume is dispatched when the animation is resumed.
+	EventResume
+)
+
+// Event describes an event of an animation.
+type Event struct {
+	Type EventType
+	// Position is the position of the frame (from 1) shown when
+	// the event is dispatched.
+	Position int
+	// Loops is how many loops have been elapsed for EventLoop.
+	Loops int
+}
+
+// Listener is the function called for the events an animation
+// dispatches.
+type Listener func(anim *Animation, e Event)
+
+// Subscription is the handle returned by Subscribe to unsubscribe
+// the listener.
+type Subscription struct {
+	id int
+}
+
+type subscriber struct {
+	id        int
+	eventType EventType
+	listener  Listener
+}
+
+// Subscribe adds the listener for the events of the type and
+// returns the handle to unsubscribe it. Listeners are called in
+// the order they were subscribed and any number of them can
+// observe the same animation.
+//
+// Clones of the animation don't keep the listeners.
+func (anim *Animation) Subscribe(eventType EventType, listener Listener) Subscription {
+	anim.lastSubscription++
+	subscribers := make([]subscriber, 0, len(anim.subscribers)+1)
+	subscribers = append(subscribers, anim.subscribers...)
+	anim.subscribers = append(subscribers, subscriber{
+		id:        anim.lastSubscription,
+		eventType: eventType,
+		listener:  listener,
+	})
+	return Subscription{id: anim.lastSubscription}
+}
+
+// Unsubscribe removes the listener of the subscription. It is safe
+// to unsubscribe from inside a listener.
+func (anim *Animation) Unsubscribe(sub Subscription) {
+	for i, s := range anim.subscribers {
+		if s.id == sub.id {
+			subscribers := make([]subscriber, 0, len(anim.subscribers)-1)
+			subscribers = append(subscribers, anim.subscribers[:i]...)
+			anim.subscribers = append(subscribers, anim.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// dispatch calls the listeners subscribed to the type of the event.
+// The slice is never modified in place, so listeners can subscribe
+// and unsubscribe while it is iterated.
+func (anim *Animation) dispatch(eventType EventType, loops int) {
+	if len(anim.subscribers) == 0 {
+		return
+	}
+	e := Event{Type: eventType, Position: anim.timeline.Position(), Loops: loops}
+	for _, s := range anim.subscribers {
+		if s.eventType == eventType {
+			s.listener(anim, e)
+		}
+	}
+}
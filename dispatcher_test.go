@@ -0,0 +1,102 @@
+package ganim8_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestSubscribe(t *testing.T) {
+	anim := ganim8.NewAnimation(mockSprite(3), time.Second)
+	anim.SetRepeat(2)
+	got := []string{}
+	names := map[ganim8.EventType]string{
+		ganim8.EventStart:        "start",
+		ganim8.EventFrameChanged: "frame",
+		ganim8.EventLoop:         "loop",
+		ganim8.EventComplete:     "complete",
+		ganim8.EventPause:        "pause",
+		ganim8.EventResume:       "resume",
+	}
+	for eventType, name := range names {
+		name := name
+		anim.Subscribe(eventType, func(a *ganim8.Animation, e ganim8.Event) {
+			got = append(got, fmt.Sprintf("%s:%d:%d", name, e.Position, e.Loops))
+		})
+	}
+
+	anim.UpdateWithDelta(time.Second)
+	anim.Pause()
+	anim.Resume()
+	anim.UpdateWithDelta(time.Second * 10)
+	require.Equal(t, []string{
+		"start:1:0", "frame:2:0",
+		"pause:2:0", "resume:2:0",
+		"loop:3:2", "pause:3:0", "complete:3:0",
+		"frame:3:0",
+	}, got)
+
+	got = got[:0]
+	anim.Restart()
+	require.Equal(t, []string{"start:1:0", "resume:1:0", "frame:1:0"}, got)
+}
+
+func TestFrameChangedEvents(t *testing.T) {
+	var tests = []struct {
+		name   string
+		action func(anim *ganim8.Animation)
+		want   []string
+	}{
+		{"restarts a paused animation", (*ganim8.Animation).Restart, []string{"start:1", "resume:1", "frame:1"}},
+		{"goes to a frame", func(anim *ganim8.Animation) { anim.GoToFrame(3) }, []string{"frame:3"}},
+		{"sets the durations", func(anim *ganim8.Animation) { anim.SetDurations(time.Second) }, []string{"frame:1"}},
+		{"sets the direction", func(anim *ganim8.Animation) {
+			anim.SetTime(0)
+			anim.SetDirection(ganim8.Reverse)
+		}, []string{"frame:1", "frame:3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anim := ganim8.NewAnimation(mockSprite(3), time.Second)
+			anim.UpdateWithDelta(time.Second)
+			anim.Pause()
+			got := []string{}
+			names := map[ganim8.EventType]string{
+				ganim8.EventStart:        "start",
+				ganim8.EventFrameChanged: "frame",
+				ganim8.EventResume:       "resume",
+			}
+			for eventType, name := range names {
+				name := name
+				anim.Subscribe(eventType, func(a *ganim8.Animation, e ganim8.Event) {
+					got = append(got, fmt.Sprintf("%s:%d", name, e.Position))
+				})
+			}
+			tt.action(anim)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	anim := ganim8.NewAnimation(mockSprite(3), time.Second)
+	calls := []string{}
+	var a ganim8.Subscription
+	a = anim.Subscribe(ganim8.EventFrameChanged, func(anim *ganim8.Animation, e ganim8.Event) {
+		calls = append(calls, "a")
+		anim.Unsubscribe(a)
+	})
+	anim.Subscribe(ganim8.EventFrameChanged, func(anim *ganim8.Animation, e ganim8.Event) {
+		calls = append(calls, "b")
+	})
+	anim.UpdateWithDelta(time.Second)
+	anim.UpdateWithDelta(time.Second)
+	require.Equal(t, []string{"a", "b", "b"}, calls)
+
+	clone := anim.Clone()
+	clone.UpdateWithDelta(time.Second)
+	require.Equal(t, []string{"a", "b", "b"}, calls)
+}
@@ -0,0 +1,187 @@
+package ganim8
+
+import "math"
+
+// The easings below are Interpolation functions usable both for the
+// keyframes of tracks and for tweens. They map 0 to 0 and 1 to 1;
+// back and elastic overshoot in between.
+
+const (
+	backC1 = 1.70158
+	backC2 = backC1 * 1.525
+	backC3 = backC1 + 1
+)
+
+// EaseInQuad accelerates from zero velocity.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero velocity.
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad accelerates until halfway and then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// EaseInCubic accelerates from zero velocity.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero velocity.
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic accelerates until halfway and then decelerates.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// EaseInBack pulls back before moving forward.
+func EaseInBack(t float64) float64 {
+	return backC3*t*t*t - backC1*t*t
+}
+
+// EaseOutBack overshoots the end before settling on it.
+func EaseOutBack(t float64) float64 {
+	return 1 + backC3*math.Pow(t-1, 3) + backC1*math.Pow(t-1, 2)
+}
+
+// EaseInOutBack pulls back at the start and overshoots the end.
+func EaseInOutBack(t float64) float64 {
+	if t < 0.5 {
+		return math.Pow(2*t, 2) * ((backC2+1)*2*t - backC2) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((backC2+1)*(2*t-2)+backC2) + 2) / 2
+}
+
+// EaseInElastic winds up like a spring before moving.
+func EaseInElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return math.Round(t)
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((10*t-10.75)*2*math.Pi/3)
+}
+
+// EaseOutElastic springs past the end and oscillates around it.
+func EaseOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return math.Round(t)
+	}
+	return math.Pow(2, -10*t)*math.Sin((10*t-0.75)*2*math.Pi/3) + 1
+}
+
+// EaseInOutElastic winds up at the start and oscillates at the end.
+func EaseInOutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return math.Round(t)
+	}
+	if t < 0.5 {
+		return -math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*2*math.Pi/4.5) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*2*math.Pi/4.5)/2 + 1
+}
+
+// EaseInBounce bounces off the start before moving.
+func EaseInBounce(t float64) float64 {
+	return 1 - EaseOutBounce(1-t)
+}
+
+// EaseOutBounce bounces on the end like a dropped ball.
+func EaseOutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
+
+// EaseInOutBounce bounces off the start and on the end.
+func EaseInOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - EaseOutBounce(1-2*t)) / 2
+	}
+	return (1 + EaseOutBounce(2*t-1)) / 2
+}
+
+// Steps returns an interpolation jumping in n equal steps, each one
+// held until the next, like the steps of CSS.
+func Steps(n int) Interpolation {
+	if n < 1 {
+		n = 1
+	}
+	return func(t float64) float64 {
+		if t >= 1 {
+			return 1
+		}
+		return math.Floor(t*float64(n)) / float64(n)
+	}
+}
+
+// CubicBezier returns the interpolation following the cubic Bézier
+// curve from (0, 0) to (1, 1) with the control points (x1, y1) and
+// (x2, y2), like the cubic-bezier of CSS. x1 and x2 are clamped
+// between 0 and 1 so that the curve is a function of time.
+func CubicBezier(x1, y1, x2, y2 float64) Interpolation {
+	x1 = math.Max(0, math.Min(1, x1))
+	x2 = math.Max(0, math.Min(1, x2))
+	// the polynomial coefficients of both coordinates
+	cx := 3 * x1
+	bx := 3*(x2-x1) - cx
+	ax := 1 - cx - bx
+	cy := 3 * y1
+	by := 3*(y2-y1) - cy
+	ay := 1 - cy - by
+	curve := func(a, b, c, s float64) float64 {
+		return ((a*s+b)*s + c) * s
+	}
+	return func(t float64) float64 {
+		if t <= 0 || t >= 1 {
+			return curve(ay, by, cy, math.Max(0, math.Min(1, t)))
+		}
+		// solve x(s) = t with Newton's method, falling back to a
+		// bisection where the slope is too flat
+		s := t
+		for i := 0; i < 8; i++ {
+			dx := curve(ax, bx, cx, s) - t
+			if math.Abs(dx) < 1e-7 {
+				return curve(ay, by, cy, s)
+			}
+			slope := (3*ax*s+2*bx)*s + cx
+			if math.Abs(slope) < 1e-6 {
+				break
+			}
+			s -= dx / slope
+		}
+		lo, hi := 0.0, 1.0
+		s = t
+		for i := 0; i < 64 && hi-lo > 1e-9; i++ {
+			if curve(ax, bx, cx, s) < t {
+				lo = s
+			} else {
+				hi = s
+			}
+			s = (lo + hi) / 2
+		}
+		return curve(ay, by, cy, s)
+	}
+}
@@ -0,0 +1,53 @@
+package ganim8_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestEasings(t *testing.T) {
+	var tests = []struct {
+		name string
+		ease ganim8.Interpolation
+		half float64
+	}{
+		{"Linear", ganim8.Linear, 0.5},
+		{"Smooth", ganim8.Smooth, 0.5},
+		{"EaseInQuad", ganim8.EaseInQuad, 0.25},
+		{"EaseOutQuad", ganim8.EaseOutQuad, 0.75},
+		{"EaseInOutQuad", ganim8.EaseInOutQuad, 0.5},
+		{"EaseInCubic", ganim8.EaseInCubic, 0.125},
+		{"EaseOutCubic", ganim8.EaseOutCubic, 0.875},
+		{"EaseInOutCubic", ganim8.EaseInOutCubic, 0.5},
+		{"EaseInBack", ganim8.EaseInBack, -0.0876975},
+		{"EaseOutBack", ganim8.EaseOutBack, 1.0876975},
+		{"EaseInOutBack", ganim8.EaseInOutBack, 0.5},
+		{"EaseInElastic", ganim8.EaseInElastic, -0.0156250},
+		{"EaseOutElastic", ganim8.EaseOutElastic, 1.0156250},
+		{"EaseInOutElastic", ganim8.EaseInOutElastic, 0.5},
+		{"EaseInBounce", ganim8.EaseInBounce, 0.234375},
+		{"EaseOutBounce", ganim8.EaseOutBounce, 0.765625},
+		{"EaseInOutBounce", ganim8.EaseInOutBounce, 0.5},
+		{"Steps", ganim8.Steps(4), 0.5},
+		{"CubicBezier", ganim8.CubicBezier(0.25, 0.1, 0.25, 1), 0.8024033},
+		{"linear CubicBezier", ganim8.CubicBezier(0, 0, 1, 1), 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, 0, tt.ease(0), 1e-9)
+			require.InDelta(t, 1, tt.ease(1), 1e-9)
+			require.InDelta(t, tt.half, tt.ease(0.5), 1e-6)
+		})
+	}
+}
+
+func TestSteps(t *testing.T) {
+	steps := ganim8.Steps(4)
+	for _, tt := range []struct{ t, want float64 }{
+		{0.1, 0}, {0.25, 0.25}, {0.49, 0.25}, {0.99, 0.75}, {1, 1},
+	} {
+		require.Equal(t, tt.want, steps(tt.t), "at %v", tt.t)
+	}
+}
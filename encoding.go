@@ -0,0 +1,297 @@
+package ganim8
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+// binaryVersion is the first byte of the binary encoding of the
+// playback state, bumped whenever the layout changes.
+const binaryVersion = 1
+
+var statusNames = map[string]Status{
+	"playing": Playing,
+	"paused":  Paused,
+}
+
+// animationState is the playback state of an animation as it is
+// encoded in save files.
+type animationState struct {
+	Name      string        `json:"name"`
+	Frame     int           `json:"frame"`
+	Timer     time.Duration `json:"timer"`
+	Status    string        `json:"status"`
+	Direction string        `json:"direction"`
+	Speed     float64       `json:"speed"`
+	Repeat    int           `json:"repeat,omitempty"`
+	Loops     int           `json:"loops,omitempty"`
+	MotionX   float64       `json:"motionX,omitempty"`
+	MotionY   float64       `json:"motionY,omitempty"`
+}
+
+// SetName sets the stable name the playback state of the animation
+// is saved with. Animations returned by a Loader are named after
+// the name they are registered with.
+func (anim *Animation) SetName(name string) {
+	anim.name = name
+}
+
+// Name returns the name the playback state of the animation is
+// saved with.
+func (anim *Animation) Name() string {
+	return anim.name
+}
+
+// MarshalJSON encodes the playback state of the animation (the
+// current frame, the timer, the status, the speed, the direction,
+// the loops and the root motion not taken yet) with its name.
+func (anim *Animation) MarshalJSON() ([]byte, error) {
+	s := anim.timeline.Save()
+	return json.Marshal(animationState{
+		Name:      anim.name,
+		Frame:     s.Position + 1,
+		Timer:     s.Timer,
+		Status:    keyOf(statusNames, s.Status),
+		Direction: keyOf(directionNames, s.Direction),
+		Speed:     s.Speed,
+		Repeat:    s.Repeat,
+		Loops:     s.Loops,
+		MotionX:   anim.motionX,
+		MotionY:   anim.motionY,
+	})
+}
+
+// UnmarshalJSON restores the playback state encoded by MarshalJSON.
+// The animation needs to have the same name as the encoded one,
+// like the animations returned by Loader.Animation.
+func (anim *Animation) UnmarshalJSON(data []byte) error {
+	var state animationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	status, ok := statusNames[state.Status]
+	if !ok {
+		return fmt.Errorf("unknown status %q", state.Status)
+	}
+	direction, ok := directionNames[state.Direction]
+	if !ok {
+		return fmt.Errorf("unknown direction %q", state.Direction)
+	}
+	err := anim.restoreState(state.Name, timeline.Snapshot{
+		Position:  state.Frame - 1,
+		Timer:     state.Timer,
+		Status:    status,
+		Direction: direction,
+		Speed:     state.Speed,
+		Repeat:    state.Repeat,
+		Loops:     state.Loops,
+	})
+	if err != nil {
+		return err
+	}
+	anim.motionX, anim.motionY = state.MotionX, state.MotionY
+	return nil
+}
+
+// MarshalBinary encodes the same playback state as MarshalJSON in
+// a compact binary form.
+func (anim *Animation) MarshalBinary() ([]byte, error) {
+	s := anim.timeline.Save()
+	b := []byte{binaryVersion}
+	b = appendUvarint(b, uint64(len(anim.name)))
+	b = append(b, anim.name...)
+	b = appendVarint(b, int64(s.Position))
+	b = appendVarint(b, int64(s.Timer))
+	b = append(b, byte(s.Status), byte(s.Direction))
+	b = appendUvarint(b, math.Float64bits(s.Speed))
+	b = appendVarint(b, int64(s.Repeat))
+	b = appendVarint(b, int64(s.Loops))
+	b = appendUvarint(b, math.Float64bits(anim.motionX))
+	b = appendUvarint(b, math.Float64bits(anim.motionY))
+	return b, nil
+}
+
+// UnmarshalBinary restores the playback state encoded by
+// MarshalBinary. The animation needs to have the same name as the
+// encoded one.
+func (anim *Animation) UnmarshalBinary(data []byte) error {
+	d := binaryDecoder{r: bytes.NewReader(data)}
+	version := d.byte()
+	if d.err == nil && version != binaryVersion {
+		return fmt.Errorf("unknown binary version %d", version)
+	}
+	name := d.string()
+	s := timeline.Snapshot{
+		Position:  int(d.varint()),
+		Timer:     time.Duration(d.varint()),
+		Status:    Status(d.byte()),
+		Direction: Direction(d.byte()),
+		Speed:     math.Float64frombits(d.uvarint()),
+		Repeat:    int(d.varint()),
+		Loops:     int(d.varint()),
+	}
+	motionX := math.Float64frombits(d.uvarint())
+	motionY := math.Float64frombits(d.uvarint())
+	if d.err != nil {
+		return d.err
+	}
+	if err := anim.restoreState(name, s); err != nil {
+		return err
+	}
+	anim.motionX, anim.motionY = motionX, motionY
+	return nil
+}
+
+// binaryDecoder reads the values of the binary encoding keeping
+// the first error.
+type binaryDecoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (d *binaryDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	var b byte
+	b, d.err = d.r.ReadByte()
+	return b
+}
+
+func (d *binaryDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	var v int64
+	v, d.err = binary.ReadVarint(d.r)
+	return v
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	var v uint64
+	v, d.err = binary.ReadUvarint(d.r)
+	return v
+}
+
+func (d *binaryDecoder) string() string {
+	n := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if n > uint64(d.r.Len()) {
+		d.err = io.ErrUnexpectedEOF
+		return ""
+	}
+	b := make([]byte, n)
+	_, d.err = io.ReadFull(d.r, b)
+	return string(b)
+}
+
+// RestoreAnimation returns a new animation registered with the
+// name encoded in the playback state with the state restored.
+// data is encoded by Animation.MarshalJSON or MarshalBinary.
+func (l *Loader) RestoreAnimation(data []byte) (*Animation, error) {
+	name, err := decodeName(data)
+	if err != nil {
+		return nil, err
+	}
+	anim, err := l.Animation(name)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON(data) {
+		err = anim.UnmarshalJSON(data)
+	} else {
+		err = anim.UnmarshalBinary(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return anim, nil
+}
+
+// decodeName returns the name of the animation in the encoded
+// playback state.
+func decodeName(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", io.ErrUnexpectedEOF
+	}
+	if isJSON(data) {
+		var state animationState
+		err := json.Unmarshal(data, &state)
+		return state.Name, err
+	}
+	d := binaryDecoder{r: bytes.NewReader(data)}
+	d.byte()
+	name := d.string()
+	return name, d.err
+}
+
+// isJSON reports whether the encoded playback state is JSON rather
+// than binary, which always starts with its version.
+func isJSON(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '{'
+}
+
+// restoreState validates the decoded state before restoring it, as
+// it comes from a file that may not match the loaded assets.
+func (anim *Animation) restoreState(name string, s timeline.Snapshot) error {
+	if name != anim.name {
+		return fmt.Errorf("state of animation %q can't be restored to animation %q", name, anim.name)
+	}
+	if s.Position < 0 || s.Position >= anim.timeline.Length() {
+		return fmt.Errorf("animation %q has no frame %d", name, s.Position+1)
+	}
+	if s.Status != Playing && s.Status != Paused {
+		return fmt.Errorf("unknown status %d", s.Status)
+	}
+	if s.Direction < Forward || s.Direction > PingPongReverse {
+		return fmt.Errorf("unknown direction %d", s.Direction)
+	}
+	if s.Timer < 0 {
+		return errors.New("timer is negative")
+	}
+	if math.IsNaN(s.Speed) || math.IsInf(s.Speed, 0) {
+		return fmt.Errorf("speed %v is not a finite number", s.Speed)
+	}
+	previous := anim.timeline.Save()
+	anim.timeline.Restore(s)
+	if s.Timer > anim.timeline.Cycle() {
+		anim.timeline.Restore(previous)
+		return fmt.Errorf("timer %v is out of the cycle of animation %q", s.Timer, name)
+	}
+	anim.started = true
+	anim.motionIndex = s.Position
+	return nil
+}
+
+func appendVarint(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], v)]...)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func keyOf[T comparable](m map[string]T, v T) string {
+	for key, value := range m {
+		if value == v {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package ganim8_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockLoader(t *testing.T) *ganim8.Loader {
+	fsys := fstest.MapFS{
+		"sheet.png": {Data: mockPNG(t, 64, 16)},
+		"sheet.json": {Data: []byte(`{
+			"image": "sheet.png",
+			"sprites": { "walk": { "grid": { "frameWidth": 16, "frameHeight": 16 }, "frames": ["1-4", 1] } },
+			"animations": { "walk": { "durations": 100, "direction": "pingpong" } }
+		}`)},
+	}
+	loader := ganim8.NewLoader(fsys)
+	require.NoError(t, loader.Load("sheet.json"))
+	return loader
+}
+
+func TestMarshalAnimation(t *testing.T) {
+	var tests = []struct {
+		name    string
+		marshal func(anim *ganim8.Animation) ([]byte, error)
+	}{
+		{"encodes json", func(anim *ganim8.Animation) ([]byte, error) { return json.Marshal(anim) }},
+		{"encodes binary", func(anim *ganim8.Animation) ([]byte, error) { return anim.MarshalBinary() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := mockLoader(t)
+			anim, err := loader.Animation("walk")
+			require.NoError(t, err)
+			anim.SetSpeed(1.5)
+			anim.SetRootMotion("1-4", 2, 1)
+			anim.UpdateWithDelta(time.Millisecond * 330)
+			anim.Pause()
+			data, err := tt.marshal(anim)
+			require.NoError(t, err)
+
+			restored, err := mockLoader(t).RestoreAnimation(data)
+			require.NoError(t, err)
+			require.Equal(t, anim.Save(), restored.Save())
+			require.Equal(t, ganim8.PingPong, restored.Direction())
+			require.Equal(t, anim.Position(), restored.Position())
+			dx, dy := restored.TakeRootMotion()
+			require.Equal(t, []float64{10, 5}, []float64{dx, dy})
+		})
+	}
+}
+
+func TestUnmarshalAnimationErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		data string
+	}{
+		{"fails on another animation", `{"name":"run","frame":1,"timer":0,"status":"playing","direction":"forward","speed":1}`},
+		{"fails on missing frames", `{"name":"walk","frame":5,"timer":0,"status":"playing","direction":"forward","speed":1}`},
+		{"fails on timers out of the cycle", `{"name":"walk","frame":1,"timer":1000000000,"status":"playing","direction":"forward","speed":1}`},
+		{"fails on unknown directions", `{"name":"walk","frame":1,"timer":0,"status":"playing","direction":"up","speed":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anim, err := mockLoader(t).Animation("walk")
+			require.NoError(t, err)
+			before := anim.Save()
+			require.Error(t, json.Unmarshal([]byte(tt.data), anim))
+			require.Equal(t, before, anim.Save())
+		})
+	}
+
+	anim, err := mockLoader(t).Animation("walk")
+	require.NoError(t, err)
+	data, err := anim.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, anim.UnmarshalBinary(data[:len(data)-1]))
+
+	anim.SetSpeed(math.NaN())
+	data, err = anim.MarshalBinary()
+	require.NoError(t, err)
+	anim.SetSpeed(1)
+	require.Error(t, anim.UnmarshalBinary(data))
+	require.Equal(t, 1.0, anim.Speed())
+}
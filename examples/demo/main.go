@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bytes"
 	"embed"
-	"image"
 	_ "image/png"
 	"log"
 	"math"
@@ -64,7 +62,11 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 }
 
 func (g *Game) setupAnimations() {
-	img := ebiten.NewImageFromImage(readImage("assets/1945.png"))
+	loader := ganim8.NewLoader(assets)
+	img, err := loader.Image("assets/1945.png")
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	//                    frame(w,h), image(w,h), offsets, border
 	g32 := ganim8.NewGrid(32, 32, 1024, 768, 3, 3, 1)
@@ -91,32 +93,20 @@ func (g *Game) setupAnimations() {
 	//                   frame(w,h), image(w,h), offsets, border
 	gs := ganim8.NewGrid(32, 98, 1024, 768, 366, 102, 1)
 
-	g.submarine = ganim8.New(img, gs.Frames("1-7", 1, "6-2", 1),
+	g.submarine = ganim8.New(img, gs.Frames("1-7", 1),
 		// individual frame delays
 		map[string]time.Duration{
-			"1":    time.Second * 2,
-			"2-6":  time.Millisecond * 100,
-			"7":    time.Second * 1,
-			"8-12": time.Millisecond * 100,
+			"1":   time.Second * 2,
+			"2-6": time.Millisecond * 100,
+			"7":   time.Second * 1,
 		})
+	// emerge and submerge without duplicating the frames
+	g.submarine.SetDirection(ganim8.PingPong)
 }
 
 //go:embed assets/*
 var assets embed.FS
 
-func readImage(file string) image.Image {
-	b, _ := assets.ReadFile(file)
-	return bytes2Image(&b)
-}
-
-func bytes2Image(rawImage *[]byte) image.Image {
-	img, format, error := image.Decode(bytes.NewReader(*rawImage))
-	if error != nil {
-		log.Fatal("Bytes2Image Failed: ", format, error)
-	}
-	return img
-}
-
 func main() {
 	ebiten.SetWindowSize(screenWidth, screenHeight)
 	if err := ebiten.RunGame(NewGame()); err != nil {
@@ -0,0 +1,116 @@
+package ganim8
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// DefaultGIFDelay is the delay used for GIF frames that specify
+// a delay of zero, the same way most browsers handle them.
+var DefaultGIFDelay = time.Millisecond * 100
+
+// DecodeGIF reads an animated GIF from r and returns an animation
+// that plays its frames.
+func DecodeGIF(r io.Reader) (*Animation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnimationFromGIF(g)
+}
+
+// NewAnimationFromGIF returns a new animation built from the
+// decoded GIF.
+//
+// The frames are composited into a single sheet image (one
+// column per frame) so that disposal methods and frame offsets
+// are applied the same way a browser would show them.
+// The durations come from the GIF delays and the animation
+// pauses at the last frame after playing as many times as the
+// GIF loop count specifies.
+func NewAnimationFromGIF(g *gif.GIF) (*Animation, error) {
+	sheet, frames, durations, err := ComposeGIF(g)
+	if err != nil {
+		return nil, err
+	}
+	spr := newSpriteWithSource(ebiten.NewImageFromImage(sheet), sheet, frames)
+	anim := NewAnimation(spr, durations)
+	anim.SetRepeat(gifRepeat(g.LoopCount))
+	return anim, nil
+}
+
+// ComposeGIF composites the frames of the GIF into a sheet image
+// and returns it with the frame rectangles and durations.
+func ComposeGIF(g *gif.GIF) (*image.RGBA, []*image.Rectangle, []time.Duration, error) {
+	if len(g.Image) == 0 {
+		return nil, nil, nil, errors.New("gif has no frames")
+	}
+	w, h := g.Config.Width, g.Config.Height
+	if w == 0 || h == 0 {
+		b := g.Image[0].Bounds()
+		w, h = b.Max.X, b.Max.Y
+	}
+
+	n := len(g.Image)
+	sheet := image.NewRGBA(image.Rect(0, 0, w*n, h))
+	canvas := image.NewRGBA(image.Rect(0, 0, w, h))
+	var previous *image.RGBA
+	frames := make([]*image.Rectangle, n)
+	durations := make([]time.Duration, n)
+
+	for i, img := range g.Image {
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		if disposal == gif.DisposalPrevious {
+			if previous == nil {
+				previous = image.NewRGBA(canvas.Bounds())
+			}
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, img.Bounds(), img, img.Bounds().Min, draw.Over)
+
+		r := image.Rect(i*w, 0, (i+1)*w, h)
+		draw.Draw(sheet, r, canvas, image.Point{}, draw.Src)
+		frames[i] = &r
+
+		delay := 0
+		if i < len(g.Delay) {
+			delay = g.Delay[i]
+		}
+		durations[i] = DefaultGIFDelay
+		if delay > 0 {
+			durations[i] = time.Millisecond * 10 * time.Duration(delay)
+		}
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			copy(canvas.Pix, previous.Pix)
+		}
+	}
+
+	return sheet, frames, durations, nil
+}
+
+// gifRepeat returns the repeat count for the loop count of a GIF.
+// Zero means forever, -1 means to play only once and n means to
+// play n+1 times.
+func gifRepeat(loopCount int) int {
+	if loopCount == 0 {
+		return 0
+	}
+	if loopCount < 0 {
+		return 1
+	}
+	return loopCount + 1
+}
@@ -0,0 +1,85 @@
+package ganim8_test
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+var (
+	red   = color.RGBA{0xff, 0, 0, 0xff}
+	green = color.RGBA{0, 0xff, 0, 0xff}
+)
+
+func mockGIF(disposal byte) *gif.GIF {
+	palette := color.Palette{color.Transparent, red, green}
+	f1 := image.NewPaletted(image.Rect(0, 0, 2, 1), palette)
+	f1.SetColorIndex(0, 0, 1)
+	f2 := image.NewPaletted(image.Rect(1, 0, 2, 1), palette)
+	f2.SetColorIndex(1, 0, 2)
+	return &gif.GIF{
+		Image:     []*image.Paletted{f1, f2},
+		Delay:     []int{7, 0},
+		Disposal:  []byte{disposal, 0},
+		Config:    image.Config{Width: 2, Height: 1},
+		LoopCount: -1,
+	}
+}
+
+func TestComposeGIF(t *testing.T) {
+	var tests = []struct {
+		name     string
+		disposal byte
+		want     color.Color
+	}{
+		{"keeps the previous frame", gif.DisposalNone, red},
+		{"clears the frame to the background", gif.DisposalBackground, color.RGBA{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet, frames, durations, err := ganim8.ComposeGIF(mockGIF(tt.disposal))
+			require.NoError(t, err)
+			require.Equal(t, image.Rect(0, 0, 4, 1), sheet.Bounds())
+			require.Equal(t, image.Rect(2, 0, 4, 1), *frames[1])
+			require.Equal(t, tt.want, sheet.At(2, 0))
+			require.Equal(t, green, sheet.At(3, 0))
+			require.Equal(t, []time.Duration{time.Millisecond * 70, ganim8.DefaultGIFDelay}, durations)
+		})
+	}
+}
+
+func TestComposeGIFDisposalPrevious(t *testing.T) {
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	palette := color.Palette{color.Transparent, red, green, blue}
+	f1 := image.NewPaletted(image.Rect(0, 0, 2, 1), palette)
+	f1.SetColorIndex(0, 0, 1)
+	f2 := image.NewPaletted(image.Rect(0, 0, 2, 1), palette)
+	f2.SetColorIndex(0, 0, 2)
+	f2.SetColorIndex(1, 0, 2)
+	f3 := image.NewPaletted(image.Rect(1, 0, 2, 1), palette)
+	f3.SetColorIndex(1, 0, 3)
+	sheet, _, _, err := ganim8.ComposeGIF(&gif.GIF{
+		Image:    []*image.Paletted{f1, f2, f3},
+		Delay:    []int{1, 1, 1},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalPrevious, gif.DisposalNone},
+		Config:   image.Config{Width: 2, Height: 1},
+	})
+	require.NoError(t, err)
+
+	// the second frame is shown as drawn, then the canvas goes back
+	// to the first frame before the third one is drawn
+	require.Equal(t, green, sheet.At(2, 0))
+	require.Equal(t, green, sheet.At(3, 0))
+	require.Equal(t, red, sheet.At(4, 0))
+	require.Equal(t, blue, sheet.At(5, 0))
+}
+
+func TestComposeGIFWithoutFrames(t *testing.T) {
+	_, _, _, err := ganim8.ComposeGIF(&gif.GIF{})
+	require.Error(t, err)
+}
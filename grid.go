@@ -6,7 +6,6 @@ import (
 	"image"
 	_ "image/png"
 	"log"
-	"regexp"
 	"strconv"
 )
 
@@ -26,11 +25,8 @@ type frameCache map[string]map[int]map[int]*image.Rectangle
 
 var _frames frameCache
 
-var intervalMatcher regexp.Regexp
-
 func init() {
 	_frames = make(map[string]map[int]map[int]*image.Rectangle)
-	intervalMatcher = *regexp.MustCompile("^([0-9]+)-([0-9]+)$")
 }
 
 // Grid represents a grid
@@ -112,8 +108,16 @@ func (g *Grid) createFrame(x, y int) *image.Rectangle {
 }
 
 func (g *Grid) getOrCreateFrame(x, y int) *image.Rectangle {
+	frame, err := g.tryGetOrCreateFrame(x, y)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return frame
+}
+
+func (g *Grid) tryGetOrCreateFrame(x, y int) (*image.Rectangle, error) {
 	if x < 1 || x > g.width || y < 1 || y > g.height {
-		log.Fatal(fmt.Sprintf("There is no frame for x=%d, y=%d", x, y))
+		return nil, fmt.Errorf("There is no frame for x=%d, y=%d", x, y)
 	}
 	key := g.key
 	if _, ok := _frames[key]; !ok {
@@ -125,7 +129,7 @@ func (g *Grid) getOrCreateFrame(x, y int) *image.Rectangle {
 	if _, ok := _frames[key][x][y]; !ok {
 		_frames[key][x][y] = g.createFrame(x, y)
 	}
-	return _frames[key][x][y]
+	return _frames[key][x][y], nil
 }
 
 // GetFrames accepts an arbitrary number of parameters.
@@ -138,6 +142,14 @@ func (g *Grid) getOrCreateFrame(x, y int) *image.Rectangle {
 // There can be more than just two: grid:getFrames(1,1, 1,2, 1,3)
 // will return the frames in {1,1}, {1,2} and {1,3} respectively.
 func (g *Grid) GetFrames(args ...interface{}) []*image.Rectangle {
+	result, err := g.tryGetFrames(args...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return result
+}
+
+func (g *Grid) tryGetFrames(args ...interface{}) ([]*image.Rectangle, error) {
 	result := []*image.Rectangle{}
 	if len(args) == 0 {
 		for y := 1; y <= g.height; y++ {
@@ -145,18 +157,31 @@ func (g *Grid) GetFrames(args ...interface{}) []*image.Rectangle {
 				result = append(result, g.getOrCreateFrame(x, y))
 			}
 		}
-		return result
+		return result, nil
+	}
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("Frames should be given in pairs, got %d values", len(args))
 	}
 	for i := 0; i < len(args); i += 2 {
-		minx, maxx, stepx := parseInterval(args[i])
-		miny, maxy, stepy := parseInterval(args[i+1])
+		minx, maxx, stepx, err := tryParseInterval(args[i])
+		if err != nil {
+			return nil, err
+		}
+		miny, maxy, stepy, err := tryParseInterval(args[i+1])
+		if err != nil {
+			return nil, err
+		}
 		for y := miny; stepy > 0 && y <= maxy || stepy < 0 && y >= maxy; y += stepy {
 			for x := minx; stepx > 0 && x <= maxx || stepx < 0 && x >= maxx; x += stepx {
-				result = append(result, g.getOrCreateFrame(x, y))
+				frame, err := g.tryGetOrCreateFrame(x, y)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, frame)
 			}
 		}
 	}
-	return result
+	return result, nil
 }
 
 // Width returns the width of the grid
@@ -0,0 +1,160 @@
+package ganim8
+
+import (
+	"math"
+	"time"
+)
+
+// Group updates, pauses and scales the time of several animations
+// together, e.g. the body, the cape and the hair of a character,
+// while each of them keeps its own frames and speed.
+//
+// The group owns the clock of its members: updating a member
+// directly only catches it up with the group, and pausing or
+// resuming a member pauses or resumes the whole group, so that the
+// members never drift from each other. Members in tick mode advance
+// by one tick per Update of the group, or per DefaultTick of the
+// delta of UpdateWithDelta, scaled by the time scale.
+type Group struct {
+	members   []*Animation
+	timeScale float64
+	status    Status
+	now       time.Duration
+	ticks     float64
+}
+
+// NewGroup returns a new group of the animations.
+func NewGroup(anims ...*Animation) *Group {
+	g := &Group{timeScale: 1, status: Playing}
+	for _, anim := range anims {
+		g.Add(anim)
+	}
+	return g
+}
+
+// Add adds the animation to the group, removing it from the group
+// it was in. It is paused or resumed to follow the status of the
+// group.
+func (g *Group) Add(anim *Animation) {
+	if anim.group != nil {
+		anim.group.Remove(anim)
+	}
+	g.members = append(g.members, anim)
+	anim.group = g
+	anim.groupNow, anim.groupTicks = g.now, int(math.Floor(g.ticks))
+	if g.status == Paused {
+		anim.pause()
+	} else {
+		anim.resume()
+	}
+}
+
+// Remove removes the animation from the group.
+func (g *Group) Remove(anim *Animation) {
+	for i, m := range g.members {
+		if m == anim {
+			members := make([]*Animation, 0, len(g.members)-1)
+			members = append(members, g.members[:i]...)
+			g.members = append(members, g.members[i+1:]...)
+			anim.group = nil
+			return
+		}
+	}
+}
+
+// Members returns a copy of the animations of the group.
+func (g *Group) Members() []*Animation {
+	return append([]*Animation(nil), g.members...)
+}
+
+// SetTimeScale sets the multiplier applied to the deltas of the
+// group, on top of the speed of each member.
+func (g *Group) SetTimeScale(scale float64) {
+	g.timeScale = scale
+}
+
+// TimeScale returns the multiplier applied to the deltas of the
+// group.
+func (g *Group) TimeScale() float64 {
+	return g.timeScale
+}
+
+// Update advances the clock of the group by the delta of
+// Animation.Update and by one tick, both multiplied by the time
+// scale, and updates every member. It does nothing while the group
+// is paused.
+func (g *Group) Update() {
+	g.advance(time.Duration(float64(DefaultDelta)*TimeScale), 1)
+}
+
+// UpdateWithDelta advances the clock of the group by the delta
+// multiplied by the time scale and updates every member. It does
+// nothing while the group is paused.
+func (g *Group) UpdateWithDelta(elapsedTime time.Duration) {
+	g.advance(elapsedTime, float64(elapsedTime)/float64(DefaultTick))
+}
+
+func (g *Group) advance(elapsedTime time.Duration, ticks float64) {
+	if g.status != Playing {
+		return
+	}
+	if g.timeScale != 1 {
+		elapsedTime = time.Duration(float64(elapsedTime) * g.timeScale)
+	}
+	g.now += elapsedTime
+	g.ticks += ticks * g.timeScale
+	for _, anim := range g.members {
+		g.catchUp(anim)
+	}
+}
+
+// catchUp updates the member by the time the clock of the group
+// has advanced since the member was last updated.
+func (g *Group) catchUp(anim *Animation) {
+	if anim.timeline.Tick() != 0 {
+		ticks := int(math.Floor(g.ticks))
+		n := ticks - anim.groupTicks
+		anim.groupTicks = ticks
+		anim.updateTicks(n)
+		return
+	}
+	elapsedTime := g.now - anim.groupNow
+	anim.groupNow = g.now
+	anim.updateWithDelta(elapsedTime)
+}
+
+// Status returns the status of the group.
+func (g *Group) Status() Status {
+	return g.status
+}
+
+// Pause pauses the group and every member.
+func (g *Group) Pause() {
+	g.status = Paused
+	for _, anim := range g.members {
+		anim.pause()
+	}
+}
+
+// Resume resumes the group and every member.
+func (g *Group) Resume() {
+	g.status = Playing
+	for _, anim := range g.members {
+		anim.resume()
+	}
+}
+
+// Restart restarts every member from its first frame at once.
+func (g *Group) Restart() {
+	g.status = Playing
+	for _, anim := range g.members {
+		anim.Restart()
+	}
+}
+
+// SetTime moves every member to the time in its cycle.
+func (g *Group) SetTime(t time.Duration) {
+	for _, anim := range g.members {
+		anim.SetTime(t)
+	}
+}
@@ -0,0 +1,74 @@
+package ganim8_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestGroup(t *testing.T) {
+	body := ganim8.NewAnimation(mockSprite(4), time.Second)
+	cape := ganim8.NewAnimation(mockSprite(2), time.Second*2)
+	g := ganim8.NewGroup(body, cape)
+
+	g.UpdateWithDelta(time.Second)
+	require.Equal(t, []int{2, 1}, []int{body.Position(), cape.Position()})
+
+	g.Pause()
+	g.UpdateWithDelta(time.Second)
+	require.True(t, body.Status() == ganim8.Paused && cape.Status() == ganim8.Paused)
+	require.Equal(t, []int{2, 1}, []int{body.Position(), cape.Position()})
+
+	g.Resume()
+	g.SetTimeScale(2)
+	g.UpdateWithDelta(time.Second)
+	require.Equal(t, []int{4, 2}, []int{body.Position(), cape.Position()})
+	require.Equal(t, body.Timer(), cape.Timer())
+
+	hair := ganim8.NewAnimation(mockSprite(2), time.Second)
+	g.Pause()
+	g.Add(hair)
+	require.True(t, hair.Status() == ganim8.Paused)
+
+	members := g.Members()
+	g.Remove(body)
+	g.Restart()
+	require.Equal(t, []*ganim8.Animation{body, cape, hair}, members)
+	require.Equal(t, []*ganim8.Animation{cape, hair}, g.Members())
+	require.Equal(t, time.Duration(0), cape.Timer())
+	require.Equal(t, 4, body.Position())
+}
+
+func TestGroupClock(t *testing.T) {
+	body := ganim8.NewTickAnimation(mockSprite(4), 2)
+	cape := ganim8.NewAnimation(mockSprite(2), ganim8.DefaultDelta*2)
+	g := ganim8.NewGroup(body, cape)
+
+	g.Update()
+	require.Equal(t, 1, body.TickTimer())
+	require.Equal(t, ganim8.DefaultDelta, cape.Timer())
+
+	// updating a member directly only catches it up with the group
+	body.Update()
+	cape.UpdateWithDelta(time.Second)
+	require.Equal(t, 1, body.TickTimer())
+	require.Equal(t, ganim8.DefaultDelta, cape.Timer())
+
+	// pausing a member pauses the group
+	cape.Pause()
+	g.Update()
+	require.True(t, g.Status() == ganim8.Paused && body.Status() == ganim8.Paused)
+	require.Equal(t, 1, body.TickTimer())
+
+	body.Resume()
+	g.SetTimeScale(2)
+	g.Update()
+	require.Equal(t, []int{3, 2}, []int{body.TickTimer(), cape.Position()})
+	require.Equal(t, ganim8.DefaultDelta*3, cape.Timer())
+
+	g.Remove(cape)
+	cape.UpdateWithDelta(ganim8.DefaultDelta / 2)
+	require.Equal(t, ganim8.DefaultDelta*7/2, cape.Timer())
+}
@@ -0,0 +1,544 @@
+package ganim8
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/gif"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+// Loader loads images, sprites and animations from a file system
+// such as embed.FS, os.DirFS or testing/fstest.MapFS.
+//
+// Decoded images are cached by path and sprites and animations
+// are registered by name when a definition or atlas file is loaded.
+type Loader struct {
+	fsys       fs.FS
+	images     map[string]*ebiten.Image
+	sources    map[string]image.Image
+	sprites    map[string]*Sprite
+	animations map[string]*loadedAnimation
+	defs       map[string]*AnimationDef
+	files      map[string]fileKind
+}
+
+// loadedAnimation is an animation read from a file. It is updated
+// in place when the file is reloaded, and the definitions and the
+// animations created from it follow it (see AnimationDef.sync).
+type loadedAnimation struct {
+	sprite *Sprite
+	frames *timeline.Def
+	repeat int
+}
+
+type fileKind int
+
+const (
+	imageFile fileKind = iota
+	sheetFile
+	gifFile
+)
+
+// NewLoader returns a new loader reading files from fsys.
+func NewLoader(fsys fs.FS) *Loader {
+	return &Loader{
+		fsys:       fsys,
+		images:     map[string]*ebiten.Image{},
+		sources:    map[string]image.Image{},
+		sprites:    map[string]*Sprite{},
+		animations: map[string]*loadedAnimation{},
+		defs:       map[string]*AnimationDef{},
+		files:      map[string]fileKind{},
+	}
+}
+
+// Image returns the image at the path.
+// The image is decoded only once and cached afterwards.
+func (l *Loader) Image(name string) (*ebiten.Image, error) {
+	if img, ok := l.images[name]; ok {
+		return img, nil
+	}
+	src, err := l.decodeImage(name)
+	if err != nil {
+		return nil, err
+	}
+	img := ebiten.NewImageFromImage(src)
+	l.images[name] = img
+	l.sources[name] = src
+	l.files[name] = imageFile
+	return img, nil
+}
+
+// reloadImage decodes the image again and swaps it into the
+// sprites using the old one.
+func (l *Loader) reloadImage(name string) error {
+	src, err := l.decodeImage(name)
+	if err != nil {
+		return err
+	}
+	old := l.images[name]
+	img := ebiten.NewImageFromImage(src)
+	l.images[name] = img
+	l.sources[name] = src
+	for _, spr := range l.sprites {
+		if spr.image == old {
+			spr.setFrames(img, src, spr.frames)
+		}
+	}
+	return nil
+}
+
+func (l *Loader) decodeImage(name string) (image.Image, error) {
+	b, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", name, err)
+	}
+	return src, nil
+}
+
+// Sprite returns the sprite registered with the name.
+func (l *Loader) Sprite(name string) (*Sprite, error) {
+	spr, ok := l.sprites[name]
+	if !ok {
+		return nil, fmt.Errorf("sprite %q is not loaded", name)
+	}
+	return spr, nil
+}
+
+// AnimationDef returns the definition of the animation registered
+// with the name. Every call returns the same definition, which
+// follows the file when it is loaded again.
+func (l *Loader) AnimationDef(name string) (*AnimationDef, error) {
+	if def, ok := l.defs[name]; ok {
+		return def, nil
+	}
+	a, ok := l.animations[name]
+	if !ok {
+		return nil, fmt.Errorf("animation %q is not loaded", name)
+	}
+	def := &AnimationDef{
+		name:   name,
+		sprite: a.sprite,
+		frames: a.frames,
+		repeat: a.repeat,
+		loaded: a,
+		synced: *a,
+	}
+	l.defs[name] = def
+	return def, nil
+}
+
+// Animation returns a new animation registered with the name.
+// Every call returns a new animation with its own playback state,
+// but the animations share the same sprite and durations.
+// The animation is named after the name (see Animation.SetName).
+func (l *Loader) Animation(name string) (*Animation, error) {
+	def, err := l.AnimationDef(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnimationFromDef(def), nil
+}
+
+// register registers the sprites and animations read from a file.
+// Sprites and animations that are already registered are updated in
+// place, so that everything created from them plays the new frames.
+// It fails without registering anything when a sprite would no
+// longer have as many frames as an animation of another file using
+// it has durations.
+func (l *Loader) register(sprites map[string]*Sprite, animations map[string]*loadedAnimation) error {
+	next := map[*Sprite]*Sprite{}
+	for key, spr := range sprites {
+		if old, ok := l.sprites[key]; ok {
+			next[old] = spr
+		}
+	}
+	for _, key := range sortedKeys(l.animations) {
+		a := l.animations[key]
+		if _, ok := animations[key]; ok {
+			continue
+		}
+		if spr, ok := next[a.sprite]; ok && spr.length != a.frames.Length() {
+			return fmt.Errorf("animation %q has %d frames but its sprite now has %d", key, a.frames.Length(), spr.length)
+		}
+	}
+
+	live := map[*Sprite]*Sprite{}
+	for key, spr := range sprites {
+		old, ok := l.sprites[key]
+		if !ok {
+			l.sprites[key] = spr
+			continue
+		}
+		old.setFrames(spr.image, spr.source, spr.frames)
+		if spr.shapes != nil {
+			old.shapes = spr.shapes
+		}
+		live[spr] = old
+	}
+	for key, a := range animations {
+		if old, ok := live[a.sprite]; ok {
+			a.sprite = old
+		}
+		if old, ok := l.animations[key]; ok {
+			*old = *a
+			continue
+		}
+		l.animations[key] = a
+	}
+	return nil
+}
+
+// GIF returns a new animation decoded from the animated GIF at the
+// path. The GIF is decoded only once and the animation is also
+// registered with the path as its name.
+func (l *Loader) GIF(name string) (*Animation, error) {
+	if _, ok := l.animations[name]; !ok {
+		if err := l.loadGIF(name); err != nil {
+			return nil, err
+		}
+	}
+	return l.Animation(name)
+}
+
+func (l *Loader) loadGIF(name string) error {
+	b, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return err
+	}
+	g, err := gif.DecodeAll(bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to decode gif %s: %w", name, err)
+	}
+	sheet, frames, durations, err := ComposeGIF(g)
+	if err != nil {
+		return fmt.Errorf("failed to load gif %s: %w", name, err)
+	}
+	img := ebiten.NewImageFromImage(sheet)
+	l.images[name] = img
+	l.sources[name] = sheet
+	l.files[name] = gifFile
+	spr := newSpriteWithSource(img, sheet, frames)
+	return l.register(
+		map[string]*Sprite{name: spr},
+		map[string]*loadedAnimation{name: {
+			sprite: spr,
+			frames: timeline.NewDef(durations, Forward),
+			repeat: gifRepeat(g.LoopCount),
+		}},
+	)
+}
+
+// Load reads a definition or atlas file and registers the sprites
+// and animations it describes.
+//
+// Two JSON formats are supported. The first is the definition
+// format of ganim8 which describes frames with grids:
+//
+//	{
+//	  "image": "1945.png",
+//	  "sprites": {
+//	    "plane": { "grid": { "frameWidth": 64, "frameHeight": 64,
+//	      "left": 299, "top": 101, "border": 2 }, "frames": [1, "1-3"] }
+//	  },
+//	  "animations": {
+//	    "plane": { "sprite": "plane", "durations": 100 }
+//	  }
+//	}
+//
+// The frames are the arguments of Grid.Frames and the durations
+// are the same as the durations of NewAnimation in milliseconds.
+// Animations can also have a "direction" which is one of "forward",
+// "reverse", "pingpong" and "pingpong_reverse", and a "repeat"
+// count (see Animation.SetRepeat).
+// When the sprite of an animation is omitted, the sprite with the
+// same name is used.
+//
+// The second is the JSON exported by Aseprite (both hash and
+// array). The whole sheet is registered with the file name
+// without its extension and every frame tag is registered with
+// the tag name, with the direction and the repeat count of the tag.
+// The slices are imported as the shapes of the frames (see
+// Sprite.ImportAsepriteSlices).
+//
+// Image paths are relative to the file.
+func (l *Loader) Load(name string) error {
+	b, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return err
+	}
+	l.files[name] = sheetFile
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if _, ok := keys["meta"]; ok {
+		err = l.loadAseprite(name, b)
+	} else {
+		err = l.loadDefinition(name, b)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", name, err)
+	}
+	return nil
+}
+
+type definitionFile struct {
+	Image      string                         `json:"image"`
+	Sprites    map[string]spriteDefinition    `json:"sprites"`
+	Animations map[string]animationDefinition `json:"animations"`
+}
+
+type spriteDefinition struct {
+	Image  string         `json:"image"`
+	Grid   gridDefinition `json:"grid"`
+	Frames []interface{}  `json:"frames"`
+}
+
+type gridDefinition struct {
+	FrameWidth  int `json:"frameWidth"`
+	FrameHeight int `json:"frameHeight"`
+	Left        int `json:"left"`
+	Top         int `json:"top"`
+	Border      int `json:"border"`
+}
+
+type animationDefinition struct {
+	Sprite    string      `json:"sprite"`
+	Durations interface{} `json:"durations"`
+	Direction string      `json:"direction"`
+	Repeat    int         `json:"repeat"`
+}
+
+func (l *Loader) loadDefinition(name string, b []byte) error {
+	var def definitionFile
+	if err := json.Unmarshal(b, &def); err != nil {
+		return err
+	}
+	dir := path.Dir(name)
+
+	sprites := map[string]*Sprite{}
+	for _, key := range sortedKeys(def.Sprites) {
+		sd := def.Sprites[key]
+		file := sd.Image
+		if file == "" {
+			file = def.Image
+		}
+		if file == "" {
+			return fmt.Errorf("sprite %q has no image", key)
+		}
+		file = path.Join(dir, file)
+		img, err := l.Image(file)
+		if err != nil {
+			return err
+		}
+		frames, err := sd.Grid.frames(img.Bounds().Dx(), img.Bounds().Dy(), sd.Frames)
+		if err != nil {
+			return fmt.Errorf("sprite %q: %w", key, err)
+		}
+		sprites[key] = newSpriteWithSource(img, l.sources[file], frames)
+	}
+
+	animations := map[string]*loadedAnimation{}
+	for _, key := range sortedKeys(def.Animations) {
+		ad := def.Animations[key]
+		spriteName := ad.Sprite
+		if spriteName == "" {
+			spriteName = key
+		}
+		spr, ok := sprites[spriteName]
+		if !ok {
+			spr, ok = l.sprites[spriteName]
+		}
+		if !ok {
+			return fmt.Errorf("animation %q: sprite %q is not defined", key, spriteName)
+		}
+		durations, err := tryParseDurations(ad.Durations, spr.length)
+		if err != nil {
+			return fmt.Errorf("animation %q: %w", key, err)
+		}
+		direction, ok := directionNames[ad.Direction]
+		if !ok && ad.Direction != "" {
+			return fmt.Errorf("animation %q: unknown direction %q", key, ad.Direction)
+		}
+		animations[key] = &loadedAnimation{
+			sprite: spr,
+			frames: timeline.NewDef(durations, direction),
+			repeat: ad.Repeat,
+		}
+	}
+
+	return l.register(sprites, animations)
+}
+
+func (gd gridDefinition) frames(imageWidth, imageHeight int, args []interface{}) ([]*image.Rectangle, error) {
+	switch {
+	case gd.FrameWidth < 1 || gd.FrameHeight < 1:
+		return nil, fmt.Errorf("frame size should be positive, was %dx%d", gd.FrameWidth, gd.FrameHeight)
+	case gd.FrameWidth > imageWidth || gd.FrameHeight > imageHeight:
+		return nil, fmt.Errorf("frame size %dx%d is larger than the image", gd.FrameWidth, gd.FrameHeight)
+	}
+	g := NewGrid(gd.FrameWidth, gd.FrameHeight, imageWidth, imageHeight, gd.Left, gd.Top, gd.Border)
+	return g.tryGetFrames(args...)
+}
+
+type asepriteFile struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   asepriteMeta    `json:"meta"`
+}
+
+type asepriteMeta struct {
+	Image     string          `json:"image"`
+	FrameTags []asepriteTag   `json:"frameTags"`
+	Slices    []asepriteSlice `json:"slices"`
+}
+
+type asepriteTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+	Repeat    string `json:"repeat"`
+}
+
+type asepriteFrame struct {
+	Filename string `json:"filename"`
+	Frame    struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		W int `json:"w"`
+		H int `json:"h"`
+	} `json:"frame"`
+	Duration int `json:"duration"`
+}
+
+func (l *Loader) loadAseprite(name string, b []byte) error {
+	var file asepriteFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return err
+	}
+	frames, err := decodeAsepriteFrames(file.Frames)
+	if err != nil {
+		return err
+	}
+	if len(frames) == 0 {
+		return fmt.Errorf("atlas has no frames")
+	}
+	imageName := path.Join(path.Dir(name), file.Meta.Image)
+	img, err := l.Image(imageName)
+	if err != nil {
+		return err
+	}
+	src := l.sources[imageName]
+	shapes, err := asepriteShapes(file.Meta.Slices, len(frames))
+	if err != nil {
+		return err
+	}
+
+	rects := make([]*image.Rectangle, len(frames))
+	durations := make([]time.Duration, len(frames))
+	for i, f := range frames {
+		r := image.Rect(f.Frame.X, f.Frame.Y, f.Frame.X+f.Frame.W, f.Frame.Y+f.Frame.H)
+		rects[i] = &r
+		durations[i] = time.Millisecond * time.Duration(f.Duration)
+	}
+
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	sprites := map[string]*Sprite{}
+	animations := map[string]*loadedAnimation{}
+	register := func(key string, indices []int, direction Direction, repeat int) {
+		rs := make([]*image.Rectangle, len(indices))
+		ds := make([]time.Duration, len(indices))
+		ss := make([][]Shape, len(indices))
+		for i, idx := range indices {
+			rs[i], ds[i] = rects[idx], durations[idx]
+			ss[i] = shapes[idx][:len(shapes[idx]):len(shapes[idx])]
+		}
+		sprites[key] = newSpriteWithSource(img, src, rs)
+		sprites[key].shapes = ss
+		animations[key] = &loadedAnimation{sprite: sprites[key], frames: timeline.NewDef(ds, direction), repeat: repeat}
+	}
+
+	all := make([]int, len(frames))
+	for i := range all {
+		all[i] = i
+	}
+	register(base, all, Forward, 0)
+
+	for _, tag := range file.Meta.FrameTags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return fmt.Errorf("tag %q has invalid frames %d-%d", tag.Name, tag.From, tag.To)
+		}
+		indices := []int{}
+		for i := tag.From; i <= tag.To; i++ {
+			indices = append(indices, i)
+		}
+		repeat := 0
+		if tag.Repeat != "" {
+			if repeat, err = strconv.Atoi(tag.Repeat); err != nil {
+				return fmt.Errorf("tag %q has invalid repeat %q", tag.Name, tag.Repeat)
+			}
+		}
+		register(tag.Name, indices, directionNames[tag.Direction], repeat)
+	}
+
+	return l.register(sprites, animations)
+}
+
+// decodeAsepriteFrames decodes the frames of both the array and
+// the hash format, keeping the order of the hash keys.
+func decodeAsepriteFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	var frames []asepriteFrame
+	if err := json.Unmarshal(raw, &frames); err == nil {
+		return frames, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, fmt.Errorf("frames should be an array or an object")
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var f asepriteFrame
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+		f.Filename = t.(string)
+		frames = append(frames, f)
+	}
+	return frames, nil
+}
+
+// directionNames maps the directions of the definition files and
+// the frame tags of Aseprite.
+var directionNames = map[string]Direction{
+	"forward":          Forward,
+	"reverse":          Reverse,
+	"pingpong":         PingPong,
+	"pingpong_reverse": PingPongReverse,
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
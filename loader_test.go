@@ -0,0 +1,132 @@
+package ganim8_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockPNG(t *testing.T, w, h int) []byte {
+	var b bytes.Buffer
+	require.NoError(t, png.Encode(&b, image.NewRGBA(image.Rect(0, 0, w, h))))
+	return b.Bytes()
+}
+
+func TestLoaderDefinition(t *testing.T) {
+	fsys := fstest.MapFS{
+		"assets/sheet.png": {Data: mockPNG(t, 64, 32)},
+		"assets/sheet.json": {Data: []byte(`{
+			"image": "sheet.png",
+			"sprites": {
+				"walk": { "grid": { "frameWidth": 16, "frameHeight": 16 }, "frames": ["1-4", 1] }
+			},
+			"animations": {
+				"walk": { "durations": { "1": 200, "2-4": 100 } },
+				"run": { "sprite": "walk", "durations": 50 }
+			}
+		}`)},
+	}
+	loader := ganim8.NewLoader(fsys)
+	require.NoError(t, loader.Load("assets/sheet.json"))
+
+	spr, err := loader.Sprite("walk")
+	require.NoError(t, err)
+	require.Equal(t, 4, spr.Length())
+
+	walk, err := loader.Animation("walk")
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{
+		time.Millisecond * 200, time.Millisecond * 100,
+		time.Millisecond * 100, time.Millisecond * 100,
+	}, walk.Durations())
+
+	run, err := loader.Animation("run")
+	require.NoError(t, err)
+	require.Equal(t, time.Millisecond*200, run.TotalDuration())
+	require.Same(t, walk.Sprite(), run.Sprite())
+
+	img, err := loader.Image("assets/sheet.png")
+	require.NoError(t, err)
+	img2, err := loader.Image("assets/sheet.png")
+	require.NoError(t, err)
+	require.Same(t, img, img2)
+}
+
+func TestLoaderAseprite(t *testing.T) {
+	fsys := fstest.MapFS{
+		"hero.png": {Data: mockPNG(t, 48, 16)},
+		"hero.json": {Data: []byte(`{
+			"frames": {
+				"hero 0": { "frame": { "x": 0, "y": 0, "w": 16, "h": 16 }, "duration": 100 },
+				"hero 1": { "frame": { "x": 16, "y": 0, "w": 16, "h": 16 }, "duration": 200 },
+				"hero 2": { "frame": { "x": 32, "y": 0, "w": 16, "h": 16 }, "duration": 300 }
+			},
+			"meta": {
+				"image": "hero.png",
+				"frameTags": [ { "name": "bounce", "from": 0, "to": 2, "direction": "pingpong", "repeat": "2" } ],
+				"slices": [
+					{ "name": "body", "data": "hurt", "keys": [ { "frame": 0, "bounds": { "x": 2, "y": 2, "w": 12, "h": 14 } } ] },
+					{ "name": "sword", "keys": [
+						{ "frame": 1, "bounds": { "x": 8, "y": 4, "w": 8, "h": 4 } },
+						{ "frame": 2, "bounds": { "x": 0, "y": 0, "w": 0, "h": 0 } }
+					] }
+				]
+			}
+		}`)},
+	}
+	loader := ganim8.NewLoader(fsys)
+	require.NoError(t, loader.Load("hero.json"))
+
+	hero, err := loader.Animation("hero")
+	require.NoError(t, err)
+	require.Equal(t, time.Millisecond*600, hero.TotalDuration())
+
+	bounce, err := loader.Animation("bounce")
+	require.NoError(t, err)
+	require.Equal(t, 3, bounce.Sprite().Length())
+	require.Equal(t, ganim8.PingPong, bounce.Direction())
+	require.Equal(t, 2, bounce.Repeat())
+
+	hurt := ganim8.RectShape("hurt", 2, 2, 12, 14)
+	require.Equal(t, []ganim8.Shape{hurt}, bounce.Sprite().LocalShapes(0))
+	require.Equal(t, []ganim8.Shape{hurt, ganim8.RectShape("sword", 8, 4, 8, 4)}, bounce.Sprite().LocalShapes(1))
+	require.Equal(t, []ganim8.Shape{hurt}, bounce.Sprite().LocalShapes(2))
+
+	// the sprites of the tags share the shapes of the base sprite
+	bounce.Sprite().RemoveShapes("hurt")
+	require.Equal(t, []ganim8.Shape{ganim8.RectShape("sword", 8, 4, 8, 4)}, bounce.Sprite().LocalShapes(1))
+	require.Equal(t, []ganim8.Shape{hurt, ganim8.RectShape("sword", 8, 4, 8, 4)}, hero.Sprite().LocalShapes(1))
+}
+
+func TestLoaderErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sheet.png": {Data: mockPNG(t, 32, 16)},
+		"bad-frame.json": {Data: []byte(`{
+			"image": "sheet.png",
+			"sprites": { "a": { "grid": { "frameWidth": 16, "frameHeight": 16 }, "frames": [3, 1] } }
+		}`)},
+		"bad-sprite.json": {Data: []byte(`{
+			"animations": { "a": { "sprite": "missing", "durations": 100 } }
+		}`)},
+		"bad-image.json": {Data: []byte(`{
+			"image": "missing.png",
+			"sprites": { "a": { "grid": { "frameWidth": 16, "frameHeight": 16 } } }
+		}`)},
+	}
+	var tests = []string{"bad-frame.json", "bad-sprite.json", "bad-image.json", "missing.json"}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			loader := ganim8.NewLoader(fsys)
+			require.Error(t, loader.Load(name))
+		})
+	}
+
+	_, err := ganim8.NewLoader(fsys).Animation("missing")
+	require.Error(t, err)
+}
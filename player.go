@@ -0,0 +1,155 @@
+package ganim8
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+// Player is the playback state of an AnimationDef: the current
+// frame, the timer, the status and the loops played.
+//
+// It is a small value type without callbacks: creating and updating
+// a player doesn't allocate, so crowds of units can each hold one,
+// e.g. in a slice, and share their definitions. Animation adds the
+// callbacks, events, speed and tracks on top of the same timing.
+type Player struct {
+	player timeline.Player
+	def    *AnimationDef
+	loops  int
+}
+
+// NewPlayer returns a player of the definition playing from its
+// first frame.
+func NewPlayer(def *AnimationDef) Player {
+	return Player{player: timeline.NewPlayer(def.frames), def: def}
+}
+
+// Def returns the definition played by the player.
+func (p *Player) Def() *AnimationDef {
+	return p.def
+}
+
+// Update updates the player with the delta of Animation.Update.
+func (p *Player) Update() int {
+	return p.UpdateWithDelta(time.Duration(float64(DefaultDelta) * TimeScale))
+}
+
+// UpdateWithDelta updates the player with the delta and returns
+// how many times it looped. When the definition has a repeat count,
+// the player pauses at the end once it has played that many times.
+func (p *Player) UpdateWithDelta(elapsedTime time.Duration) int {
+	p.sync()
+	if p.IsComplete() {
+		return 0
+	}
+	loops := p.player.UpdateWithDelta(elapsedTime)
+	if loops > 0 && p.def.repeat > 0 {
+		p.loops += loops
+		if p.loops >= p.def.repeat {
+			loops -= p.loops - p.def.repeat
+			p.loops = p.def.repeat
+			p.player.PauseAtEnd()
+		}
+	}
+	return loops
+}
+
+// sync moves the player to the frames of its definition if they
+// have been reloaded since (see AnimationDef).
+func (p *Player) sync() {
+	p.def.sync()
+	if p.player.Def() != p.def.frames {
+		p.player.ReplaceDef(p.def.frames)
+	}
+}
+
+// frame returns the index of the frame to draw.
+func (p *Player) frame() int {
+	p.sync()
+	return p.player.Index()
+}
+
+// IsComplete returns true if the player has played as many times
+// as the repeat count of its definition.
+func (p *Player) IsComplete() bool {
+	return p.def.repeat > 0 && p.loops >= p.def.repeat
+}
+
+// Restart moves the player to its first frame, resets the count of
+// the loops and plays it.
+func (p *Player) Restart() {
+	p.player.Restart()
+	p.loops = 0
+}
+
+// Status returns the status of the player.
+func (p *Player) Status() Status {
+	return p.player.Status()
+}
+
+// Pause pauses the player.
+func (p *Player) Pause() {
+	p.player.Pause()
+}
+
+// Resume resumes the player.
+func (p *Player) Resume() {
+	p.player.Resume()
+}
+
+// Position returns the current position of the frame.
+// The position counts from 1 (not 0).
+func (p *Player) Position() int {
+	return p.player.Position()
+}
+
+// Timer returns the time elapsed in the cycle of the player.
+func (p *Player) Timer() time.Duration {
+	return p.player.Timer()
+}
+
+// IsEnd returns true if the player is paused on the last frame.
+func (p *Player) IsEnd() bool {
+	return p.player.IsEnd()
+}
+
+// GoToFrame sets the position of the player and sets the timer at
+// the start of the frame.
+func (p *Player) GoToFrame(position int) {
+	p.player.GoToFrame(position)
+}
+
+// SetTime moves the player to the time in its cycle.
+func (p *Player) SetTime(t time.Duration) {
+	p.player.SetTime(t)
+}
+
+// Progress returns how much of the cycle of the player has been
+// played, from 0 to 1.
+func (p *Player) Progress() float64 {
+	return p.player.Progress()
+}
+
+// PauseAtEnd pauses the player and set the position to the last
+// frame.
+func (p *Player) PauseAtEnd() {
+	p.player.PauseAtEnd()
+}
+
+// PauseAtStart pauses the player and set the position to the first
+// frame.
+func (p *Player) PauseAtStart() {
+	p.player.PauseAtStart()
+}
+
+// Draw draws the current frame of the player with the options.
+func (p *Player) Draw(screen *ebiten.Image, opts *DrawOptions) {
+	p.def.sprite.Draw(screen, p.frame(), opts)
+}
+
+// Render draws the current frame of the player with the renderer.
+func (p *Player) Render(r Renderer, opts *DrawOptions) {
+	p.def.sprite.Render(r, p.frame(), opts)
+}
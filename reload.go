@@ -0,0 +1,123 @@
+package ganim8
+
+import (
+	"io/fs"
+	"sort"
+	"time"
+)
+
+// Reloader watches the files read by a Loader and reloads the
+// ones that have been modified. It is meant to be used during
+// development to tweak sprite sheets and timings without
+// restarting the game.
+//
+// The files are polled by their modification times so the loader
+// should read from a file system that reports them, like os.DirFS.
+//
+// The sprites, definitions and animations of the loader are updated
+// in place, so the animations already playing, their clones and
+// their players pick up the new frames and durations the next time
+// they are updated or drawn. A sheet that would leave an animation
+// of another file with more or fewer durations than its sprite has
+// frames fails to reload.
+type Reloader struct {
+	loader   *Loader
+	interval time.Duration
+	elapsed  time.Duration
+	mtimes   map[string]time.Time
+}
+
+// NewReloader returns a new reloader that polls the files of the
+// loader every interval.
+func NewReloader(loader *Loader, interval time.Duration) *Reloader {
+	r := &Reloader{
+		loader:   loader,
+		interval: interval,
+		mtimes:   map[string]time.Time{},
+	}
+	for name := range loader.files {
+		r.modified(name)
+	}
+	return r
+}
+
+// Update polls the files when the interval has elapsed.
+// It assumes that the time delta is DefaultDelta.
+func (r *Reloader) Update() error {
+	return r.UpdateWithDelta(DefaultDelta)
+}
+
+// UpdateWithDelta polls the files when the interval has elapsed
+// with the specified delta.
+func (r *Reloader) UpdateWithDelta(elapsedTime time.Duration) error {
+	r.elapsed += elapsedTime
+	if r.elapsed < r.interval {
+		return nil
+	}
+	r.elapsed = 0
+	_, err := r.Reload()
+	return err
+}
+
+// Reload reloads the modified files right away and reports whether
+// any of them has been reloaded. Files that fail to reload are
+// retried when they are modified again and the first error is
+// returned.
+//
+// Images are reloaded first, and when any image is modified, all
+// the definition files are reloaded as well since their frames
+// depend on the size of the images.
+func (r *Reloader) Reload() (bool, error) {
+	l := r.loader
+	names := make([]string, 0, len(l.files))
+	for name := range l.files {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := l.files[names[i]], l.files[names[j]]
+		if a != b {
+			return a < b
+		}
+		return names[i] < names[j]
+	})
+
+	var firstErr error
+	reloaded, imageReloaded := false, false
+	for _, name := range names {
+		kind := l.files[name]
+		modified := r.modified(name)
+		if !modified && !(kind == sheetFile && imageReloaded) {
+			continue
+		}
+		var err error
+		switch kind {
+		case imageFile:
+			err = l.reloadImage(name)
+			imageReloaded = imageReloaded || err == nil
+		case sheetFile:
+			err = l.Load(name)
+		case gifFile:
+			err = l.loadGIF(name)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		reloaded = true
+	}
+	return reloaded, firstErr
+}
+
+// modified records the modification time of the file and reports
+// whether it has changed since the last time.
+func (r *Reloader) modified(name string) bool {
+	info, err := fs.Stat(r.loader.fsys, name)
+	if err != nil {
+		return false
+	}
+	last, ok := r.mtimes[name]
+	r.mtimes[name] = info.ModTime()
+	return ok && !last.Equal(info.ModTime())
+}
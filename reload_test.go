@@ -0,0 +1,95 @@
+package ganim8_test
+
+import (
+	"image"
+	"strconv"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestReloader(t *testing.T) {
+	def := func(frames string, duration int) *fstest.MapFile {
+		return &fstest.MapFile{
+			Data: []byte(`{
+				"image": "sheet.png",
+				"sprites": { "walk": { "grid": { "frameWidth": 16, "frameHeight": 16 }, "frames": [` + frames + `] } },
+				"animations": { "walk": { "durations": ` + strconv.Itoa(duration) + ` } }
+			}`),
+			ModTime: time.Unix(int64(duration), 0),
+		}
+	}
+	fsys := fstest.MapFS{
+		"sheet.png":  {Data: mockPNG(t, 64, 16), ModTime: time.Unix(1, 0)},
+		"sheet.json": def(`"1-4", 1`, 100),
+		"other.json": {
+			Data:    []byte(`{ "animations": { "run": { "sprite": "walk", "durations": [100, 100, 100] } } }`),
+			ModTime: time.Unix(1, 0),
+		},
+	}
+	loader := ganim8.NewLoader(fsys)
+	require.NoError(t, loader.Load("sheet.json"))
+	spr, err := loader.Sprite("walk")
+	require.NoError(t, err)
+	early, err := loader.Animation("walk")
+	require.NoError(t, err)
+	early.GoToFrame(4)
+	walkDef, err := loader.AnimationDef("walk")
+	require.NoError(t, err)
+	player := ganim8.NewPlayer(walkDef)
+	player.GoToFrame(4)
+	reloader := ganim8.NewReloader(loader, time.Second)
+
+	anim, err := loader.Animation("walk")
+	require.NoError(t, err)
+	anim.UpdateWithDelta(time.Millisecond * 250)
+	require.Equal(t, 3, anim.Position())
+	clone := anim.Clone()
+	changed := 0
+	early.Subscribe(ganim8.EventFrameChanged, func(anim *ganim8.Animation, e ganim8.Event) {
+		changed++
+	})
+
+	reloaded, err := reloader.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	fsys["sheet.json"] = def(`"1-3", 1`, 200)
+	require.NoError(t, reloader.UpdateWithDelta(time.Millisecond*500))
+	require.Equal(t, 4, spr.Length())
+
+	require.NoError(t, reloader.UpdateWithDelta(time.Millisecond*500))
+	require.Equal(t, 3, spr.Length())
+	require.Same(t, spr, anim.Sprite())
+
+	// the animations follow on their next update or draw
+	anim.UpdateWithDelta(0)
+	require.Equal(t, time.Millisecond*600, anim.TotalDuration())
+	require.Equal(t, 3, anim.Position())
+	require.Equal(t, time.Millisecond*450, anim.Timer())
+	clone.UpdateWithDelta(0)
+	require.Equal(t, time.Millisecond*600, clone.TotalDuration())
+	require.Equal(t, 3, clone.Position())
+
+	dst := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	early.Render(ganim8.NewCPURenderer(dst), ganim8.DrawOpts(0, 0))
+	require.Equal(t, 3, early.Position())
+	require.Equal(t, 1, changed)
+
+	// so do the definitions and their players
+	require.Equal(t, time.Millisecond*600, walkDef.TotalDuration())
+	player.Render(ganim8.NewCPURenderer(dst), ganim8.DrawOpts(0, 0))
+	require.Equal(t, 3, player.Position())
+
+	// a sheet leaving an animation of another file without a frame
+	// for each of its durations fails to reload
+	require.NoError(t, loader.Load("other.json"))
+	fsys["sheet.json"] = def(`"1-2", 1`, 300)
+	reloaded, err = reloader.Reload()
+	require.Error(t, err)
+	require.False(t, reloaded)
+	require.Equal(t, 3, spr.Length())
+}
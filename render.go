@@ -0,0 +1,181 @@
+package ganim8
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Renderer is a destination that sprites can be drawn to.
+//
+// The geometry matrix is the one returned by Sprite.GeoM so that
+// every renderer applies the same transformation (position,
+// rotation, scale, origin and flip) to the frames.
+type Renderer interface {
+	// DrawFrame draws the frame of the sprite at the index.
+	DrawFrame(spr *Sprite, index int, geoM ebiten.GeoM, opts *DrawOptions)
+}
+
+// Render draws the frame at the index with the renderer.
+func (spr *Sprite) Render(r Renderer, index int, opts *DrawOptions) {
+	r.DrawFrame(spr, index, spr.GeoM(opts), opts)
+}
+
+// Render draws the current frame of the animation with the renderer.
+func (anim *Animation) Render(r Renderer, opts *DrawOptions) {
+	anim.sprite.Render(r, anim.frame(), anim.TrackOptions(opts))
+}
+
+// ImageRenderer draws sprites to an *ebiten.Image.
+type ImageRenderer struct {
+	Target *ebiten.Image
+	op     ebiten.DrawImageOptions
+}
+
+// NewImageRenderer returns a new renderer drawing to the image.
+func NewImageRenderer(target *ebiten.Image) *ImageRenderer {
+	return &ImageRenderer{Target: target}
+}
+
+// DrawFrame draws the frame of the sprite to the target image.
+func (r *ImageRenderer) DrawFrame(spr *Sprite, index int, geoM ebiten.GeoM, opts *DrawOptions) {
+	r.op.GeoM = geoM
+	r.op.ColorM = opts.ColorM
+	r.op.CompositeMode = opts.CompositeMode
+	r.Target.DrawImage(spr.subImage(index), &r.op)
+}
+
+// CPURenderer draws sprites to an *image.RGBA on the CPU without
+// a graphics context. Only the sprites having their source image,
+// like the ones created with NewSpriteFromImage or a Loader, can be
+// drawn; the others, created from an *ebiten.Image, aren't drawn
+// since their pixels can't be read without a running game, and Err
+// reports them.
+//
+// Frames are sampled with the nearest filter like ebiten does by
+// default, and the color matrix and the composite mode of the
+// DrawOptions are applied the same way.
+type CPURenderer struct {
+	Target *image.RGBA
+	err    error
+}
+
+// NewCPURenderer returns a new renderer drawing to the image.
+func NewCPURenderer(target *image.RGBA) *CPURenderer {
+	return &CPURenderer{Target: target}
+}
+
+// Err returns the error of the first frame that couldn't be drawn.
+func (r *CPURenderer) Err() error {
+	return r.err
+}
+
+// DrawFrame draws the frame of the sprite to the target image.
+func (r *CPURenderer) DrawFrame(spr *Sprite, index int, geoM ebiten.GeoM, opts *DrawOptions) {
+	src := spr.source
+	if src == nil {
+		if r.err == nil {
+			r.err = errors.New("sprite has no source image to draw on the CPU")
+		}
+		return
+	}
+	frame := *spr.frames[index]
+	w, h := float64(frame.Dx()), float64(frame.Dy())
+	if !geoM.IsInvertible() {
+		return
+	}
+	inv := geoM
+	inv.Invert()
+
+	bounds := transformedBounds(geoM, w, h).Intersect(r.Target.Bounds())
+	blend := compositeFuncs[opts.CompositeMode]
+	if blend == nil {
+		blend = compositeFuncs[ebiten.CompositeModeSourceOver]
+	}
+	colorM := opts.ColorM
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			u, v := inv.Apply(float64(x)+0.5, float64(y)+0.5)
+			if u < 0 || v < 0 || u >= w || v >= h {
+				continue
+			}
+			c := colorM.Apply(src.At(frame.Min.X+int(u), frame.Min.Y+int(v)))
+			i := r.Target.PixOffset(x, y)
+			blend(r.Target.Pix[i:i+4], color.RGBAModel.Convert(c).(color.RGBA))
+		}
+	}
+}
+
+// transformedBounds returns the bounding box of the w x h rectangle
+// transformed by the matrix.
+func transformedBounds(geoM ebiten.GeoM, w, h float64) image.Rectangle {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [][2]float64{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := geoM.Apply(p[0], p[1])
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	return image.Rect(int(math.Floor(minX)), int(math.Floor(minY)),
+		int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+}
+
+// compositeFuncs blends a premultiplied source color into the
+// destination pixel for each composite mode.
+var compositeFuncs = map[ebiten.CompositeMode]func(dst []uint8, src color.RGBA){
+	ebiten.CompositeModeClear:           porterDuff(zero, zero),
+	ebiten.CompositeModeCopy:            porterDuff(one, zero),
+	ebiten.CompositeModeDestination:     porterDuff(zero, one),
+	ebiten.CompositeModeSourceOver:      porterDuff(one, oneMinusSrcAlpha),
+	ebiten.CompositeModeDestinationOver: porterDuff(oneMinusDstAlpha, one),
+	ebiten.CompositeModeSourceIn:        porterDuff(dstAlpha, zero),
+	ebiten.CompositeModeDestinationIn:   porterDuff(zero, srcAlpha),
+	ebiten.CompositeModeSourceOut:       porterDuff(oneMinusDstAlpha, zero),
+	ebiten.CompositeModeDestinationOut:  porterDuff(zero, oneMinusSrcAlpha),
+	ebiten.CompositeModeSourceAtop:      porterDuff(dstAlpha, oneMinusSrcAlpha),
+	ebiten.CompositeModeDestinationAtop: porterDuff(oneMinusDstAlpha, srcAlpha),
+	ebiten.CompositeModeXor:             porterDuff(oneMinusDstAlpha, oneMinusSrcAlpha),
+	ebiten.CompositeModeLighter:         porterDuff(one, one),
+	ebiten.CompositeModeMultiply:        multiply,
+}
+
+type blendFactor func(srcA, dstA float64) float64
+
+func zero(srcA, dstA float64) float64             { return 0 }
+func one(srcA, dstA float64) float64              { return 1 }
+func srcAlpha(srcA, dstA float64) float64         { return srcA }
+func dstAlpha(srcA, dstA float64) float64         { return dstA }
+func oneMinusSrcAlpha(srcA, dstA float64) float64 { return 1 - srcA }
+func oneMinusDstAlpha(srcA, dstA float64) float64 { return 1 - dstA }
+
+func porterDuff(fs, fd blendFactor) func(dst []uint8, src color.RGBA) {
+	return func(dst []uint8, src color.RGBA) {
+		sa, da := float64(src.A)/0xff, float64(dst[3])/0xff
+		s, d := fs(sa, da), fd(sa, da)
+		dst[0] = blendChannel(src.R, dst[0], s, d)
+		dst[1] = blendChannel(src.G, dst[1], s, d)
+		dst[2] = blendChannel(src.B, dst[2], s, d)
+		dst[3] = blendChannel(src.A, dst[3], s, d)
+	}
+}
+
+// multiply blends with the factors of ebiten's multiply mode: the
+// source is scaled by the destination color and the destination by
+// zero, for the alpha as well as the color channels.
+func multiply(dst []uint8, src color.RGBA) {
+	for i, s := range [4]uint8{src.R, src.G, src.B, src.A} {
+		dst[i] = blendChannel(s, 0, float64(dst[i])/0xff, 0)
+	}
+}
+
+func blendChannel(s, d uint8, fs, fd float64) uint8 {
+	v := float64(s)*fs + float64(d)*fd
+	if v > 0xff {
+		return 0xff
+	}
+	return uint8(math.Round(v))
+}
@@ -0,0 +1,86 @@
+//go:build ebitentest
+
+package ganim8_test
+
+import (
+	"errors"
+	"image"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+// The tests built with the ebitentest tag run inside a game, since
+// ebiten only reads pixels back while it runs. They need a display:
+//
+//	go test -tags ebitentest .
+
+var errTestsDone = errors.New("tests done")
+
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *testGame) Update() error {
+	g.code = g.m.Run()
+	return errTestsDone
+}
+
+func (g *testGame) Draw(screen *ebiten.Image) {}
+
+func (g *testGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 1, 1
+}
+
+func TestMain(m *testing.M) {
+	g := &testGame{m: m}
+	if err := ebiten.RunGame(g); err != nil && err != errTestsDone {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
+
+func TestCPURendererMatchesEbiten(t *testing.T) {
+	var tests = []struct {
+		name  string
+		opts  *ganim8.DrawOptions
+		flipH bool
+	}{
+		{"draws at the position", ganim8.DrawOpts(1, 2), false},
+		{"flips horizontally", ganim8.DrawOpts(1, 2), true},
+		{"scales around the origin", ganim8.DrawOpts(4, 4, 0, 2, 2, 0.5, 0.5), false},
+		{"rotates around the origin", ganim8.DrawOpts(4, 4, math.Pi/2, 1, 1, 0.5, 0.5), false},
+		{"combines everything", ganim8.DrawOpts(4, 4, -math.Pi/2, 2, 2, 1, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spr := mockSourceSprite()
+			spr.SetFlipH(tt.flipH)
+			opts := *tt.opts
+			opts.ColorM.Scale(1, 0.5, 1, 0.5)
+
+			want := ebiten.NewImage(8, 8)
+			spr.Render(ganim8.NewImageRenderer(want), 0, &opts)
+			got := image.NewRGBA(image.Rect(0, 0, 8, 8))
+			r := ganim8.NewCPURenderer(got)
+			spr.Render(r, 0, &opts)
+			require.NoError(t, r.Err())
+
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					// the GPU may round the color matrix differently
+					w, g := want.RGBA64At(x, y), got.RGBA64At(x, y)
+					require.InDeltaSlice(t,
+						[]uint16{w.R >> 8, w.G >> 8, w.B >> 8, w.A >> 8},
+						[]uint16{g.R >> 8, g.G >> 8, g.B >> 8, g.A >> 8},
+						1, "pixel (%d, %d)", x, y)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package ganim8_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockSourceSprite() *ganim8.Sprite {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, green)
+	r := image.Rect(0, 0, 2, 1)
+	return ganim8.NewSpriteFromImage(src, []*image.Rectangle{&r})
+}
+
+func TestCPURenderer(t *testing.T) {
+	var tests = []struct {
+		name  string
+		opts  *ganim8.DrawOptions
+		flipH bool
+		want  []string
+	}{
+		{"draws at the position", ganim8.DrawOpts(1, 2), false, []string{
+			"........",
+			"........",
+			".rg.....",
+		}},
+		{"flips horizontally", ganim8.DrawOpts(1, 2), true, []string{
+			"........",
+			"........",
+			".gr.....",
+		}},
+		{"scales around the origin", ganim8.DrawOpts(4, 4, 0, 2, 2, 0.5, 0.5), false, []string{
+			"........",
+			"........",
+			"........",
+			"..rrgg..",
+			"..rrgg..",
+		}},
+		{"rotates around the origin", ganim8.DrawOpts(4, 4, math.Pi/2, 1, 1, 0.5, 0.5), false, []string{
+			"........",
+			"........",
+			"........",
+			"....r...",
+			"....g...",
+		}},
+		{"combines everything", ganim8.DrawOpts(4, 4, -math.Pi/2, 2, 2, 1, 0), true, []string{
+			"........",
+			"........",
+			"........",
+			"........",
+			"....rr..",
+			"....rr..",
+			"....gg..",
+			"....gg..",
+		}},
+	}
+	colors := map[byte]color.Color{'.': color.RGBA{}, 'r': red, 'g': green}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spr := mockSourceSprite()
+			spr.SetFlipH(tt.flipH)
+			dst := image.NewRGBA(image.Rect(0, 0, 8, 8))
+			spr.Render(ganim8.NewCPURenderer(dst), 0, tt.opts)
+
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					want := colors['.']
+					if y < len(tt.want) {
+						want = colors[tt.want[y][x]]
+					}
+					require.Equal(t, want, dst.At(x, y), "pixel (%d, %d)", x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestCPURendererReportsSpritesWithoutSource(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	r := ganim8.NewCPURenderer(dst)
+	mockSourceSprite().Render(r, 0, ganim8.DrawOpts(0, 0))
+	require.NoError(t, r.Err())
+
+	spr := ganim8.NewSprite(mockImg, ganim8.NewGrid(1, 1, 1, 1).Frames(1, 1))
+	spr.Render(r, 0, ganim8.DrawOpts(0, 0))
+	require.Error(t, r.Err())
+}
+
+func TestCPURendererMultiply(t *testing.T) {
+	spr := mockSourceSprite()
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	dst.Set(0, 0, color.RGBA{0x80, 0x40, 0x20, 0x80})
+	opts := ganim8.DrawOpts(0, 0)
+	opts.CompositeMode = ebiten.CompositeModeMultiply
+	spr.Render(ganim8.NewCPURenderer(dst), 0, opts)
+
+	// every channel is multiplied, the alpha too
+	require.Equal(t, color.RGBA{0x80, 0, 0, 0x80}, dst.At(0, 0))
+	require.Equal(t, color.RGBA{}, dst.At(1, 0))
+}
+
+func TestCPURendererColorM(t *testing.T) {
+	spr := mockSourceSprite()
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	opts := ganim8.DrawOpts(0, 0)
+	opts.ColorM.Scale(1, 1, 1, 0.5)
+	spr.Render(ganim8.NewCPURenderer(dst), 0, opts)
+	require.Equal(t, color.RGBA{0x7f, 0, 0, 0x7f}, dst.At(0, 0))
+}
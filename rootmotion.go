@@ -0,0 +1,106 @@
+package ganim8
+
+import (
+	"log"
+
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+// motion is the root motion of a frame.
+type motion struct {
+	dx, dy float64
+}
+
+// SetRootMotion sets how far the entity playing the animation moves
+// when it enters the frames, e.g. the steps of a dash. frames is a
+// frame number (from 1) or a range of frames like "2-4".
+//
+// The motion of the frame the animation starts on, on its first
+// update or on Restart, and of every frame entered during
+// UpdateWithDelta is added up, the frames skipped over by a large
+// delta included, until it is taken with TakeRootMotion. The motion
+// is mirrored while the sprite, or the Composite playing the
+// animation, is flipped. Playing backward takes back the motion of the
+// frames left, while seeking with GoToFrame or SetTime moves no
+// entity.
+func (anim *Animation) SetRootMotion(frames interface{}, dx, dy float64) {
+	min, max, step, err := timeline.ParseInterval(frames)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i := min; i != max+step; i += step {
+		if i < 1 || i > anim.sprite.length {
+			log.Fatalf("failed to set root motion: there is no frame %d", i)
+		}
+	}
+	if anim.motion == nil {
+		anim.motion = make([]motion, anim.sprite.length)
+		anim.timeline.SetOnEnter(anim.enter)
+	}
+	for i := min; i != max+step; i += step {
+		anim.motion[i-1] = motion{dx, dy}
+	}
+}
+
+// RootMotion returns the root motion of the frame at the position
+// (from 1) as it was set.
+func (anim *Animation) RootMotion(position int) (dx, dy float64) {
+	if position < 1 {
+		return 0, 0
+	}
+	m := anim.frameMotion(position - 1)
+	return m.dx, m.dy
+}
+
+// TakeRootMotion returns the root motion added up since it was last
+// taken, and resets it.
+func (anim *Animation) TakeRootMotion() (dx, dy float64) {
+	dx, dy = anim.motionX, anim.motionY
+	anim.motionX, anim.motionY = 0, 0
+	return dx, dy
+}
+
+// enter adds up the root motion of the frame the timeline enters,
+// or takes back the one of the frame it leaves when it is played
+// backward.
+func (anim *Animation) enter(tl *timeline.Timeline, position int) {
+	m := anim.frameMotion(position - 1)
+	if anim.backward {
+		m = anim.frameMotion(anim.motionIndex)
+		m.dx, m.dy = -m.dx, -m.dy
+	}
+	anim.motionIndex = position - 1
+	anim.addMotion(m)
+}
+
+// startMotion adds up the root motion of the frame the animation
+// starts or restarts on.
+func (anim *Animation) startMotion() {
+	if anim.motion == nil {
+		return
+	}
+	anim.motionIndex = anim.timeline.Index()
+	anim.addMotion(anim.frameMotion(anim.motionIndex))
+}
+
+// addMotion adds up the root motion, mirrored while either the
+// sprite or the composite playing the animation is flipped.
+func (anim *Animation) addMotion(m motion) {
+	if anim.sprite.flippedH != anim.flipH {
+		m.dx = -m.dx
+	}
+	if anim.sprite.flippedV != anim.flipV {
+		m.dy = -m.dy
+	}
+	anim.motionX += m.dx
+	anim.motionY += m.dy
+}
+
+// frameMotion returns the root motion of the frame at the index, or
+// none if the sprite got more frames since it was set.
+func (anim *Animation) frameMotion(index int) motion {
+	if index >= len(anim.motion) {
+		return motion{}
+	}
+	return anim.motion[index]
+}
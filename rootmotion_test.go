@@ -0,0 +1,99 @@
+package ganim8_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestRootMotion(t *testing.T) {
+	var tests = []struct {
+		name   string
+		flipH  bool
+		repeat int
+		deltas []time.Duration
+		wantX  float64
+		wantY  float64
+	}{
+		{"moves on starting and entering a frame", false, 0, []time.Duration{time.Second}, 11, 0},
+		{"adds up every frame of a loop", false, 0, []time.Duration{time.Second*4 - 1}, 16, -2},
+		{"adds up the skipped frames", false, 0, []time.Duration{time.Second * 5}, 27, -2},
+		{"mirrors the flipped sprite", true, 0, []time.Duration{time.Second * 5}, -27, -2},
+		{"takes back the frames left backward", false, 0, []time.Duration{time.Second * 2, -time.Second * 2}, 1, 0},
+		{"stops at the end of the last loop", false, 1, []time.Duration{time.Second * 100}, 16, -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := image.Rect(0, 0, 1, 1)
+			spr := ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame, &frame, &frame})
+			spr.SetFlipH(tt.flipH)
+			anim := ganim8.NewAnimation(spr, time.Second)
+			anim.SetRootMotion(1, 1, 0)
+			anim.SetRootMotion(2, 10, 0)
+			anim.SetRootMotion(3, 5, -2)
+			anim.SetRepeat(tt.repeat)
+
+			for _, delta := range tt.deltas {
+				anim.UpdateWithDelta(delta)
+			}
+			dx, dy := anim.TakeRootMotion()
+			require.Equal(t, tt.wantX, dx)
+			require.Equal(t, tt.wantY, dy)
+
+			dx, dy = anim.TakeRootMotion()
+			require.Zero(t, dx)
+			require.Zero(t, dy)
+		})
+	}
+}
+
+func TestRootMotionClone(t *testing.T) {
+	frame := image.Rect(0, 0, 1, 1)
+	anim := ganim8.NewAnimation(ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame}), time.Second)
+	anim.SetRootMotion("1-2", 3, 0)
+
+	clone := anim.Clone()
+	clone.SetRootMotion(2, 4, 0)
+	clone.UpdateWithDelta(time.Second)
+	dx, _ := clone.TakeRootMotion()
+	require.Equal(t, 7.0, dx)
+	dx, _ = anim.TakeRootMotion()
+	require.Zero(t, dx)
+	dx, _ = anim.RootMotion(2)
+	require.Equal(t, 3.0, dx)
+}
+
+func TestRootMotionSnapshot(t *testing.T) {
+	frame := image.Rect(0, 0, 1, 1)
+	anim := ganim8.NewAnimation(ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame, &frame}), time.Second)
+	anim.SetRootMotion("1-3", 1, 0)
+	anim.SetRootMotion(3, 4, 0)
+	anim.UpdateWithDelta(time.Second)
+
+	s := anim.Save()
+	anim.UpdateWithDelta(time.Second * 2)
+	dx, _ := anim.TakeRootMotion()
+	require.Equal(t, 7.0, dx)
+
+	anim.Restore(s)
+	anim.UpdateWithDelta(time.Second * 2)
+	dx, _ = anim.TakeRootMotion()
+	require.Equal(t, 7.0, dx)
+}
+
+func TestRootMotionRestart(t *testing.T) {
+	frame := image.Rect(0, 0, 1, 1)
+	anim := ganim8.NewAnimation(ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame}), time.Second)
+	anim.SetRootMotion(1, 3, 0)
+	anim.SetRootMotion(2, 4, 0)
+	anim.UpdateWithDelta(time.Second)
+	anim.TakeRootMotion()
+
+	anim.Restart()
+	anim.UpdateWithDelta(time.Second / 2)
+	dx, _ := anim.TakeRootMotion()
+	require.Equal(t, 3.0, dx)
+}
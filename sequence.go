@@ -0,0 +1,168 @@
+package ganim8
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type sequenceStep struct {
+	anim  *Animation
+	times int
+}
+
+// OnSequenceEnd is the callback function called when a sequence
+// has played all its animations.
+type OnSequenceEnd func(seq *Sequence)
+
+// Sequence plays animations one after another, e.g. "draw sword"
+// once, then "attack" twice and then "idle" forever.
+//
+// The time left in an update after an animation ends is played on
+// the next one, so a sequence plays the same way whatever the
+// deltas it is updated with.
+type Sequence struct {
+	steps  []sequenceStep
+	index  int
+	ended  bool
+	onEnd  OnSequenceEnd
+	repeat int
+}
+
+// NewSequence returns a new empty sequence.
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// Add appends the animation played the number of times to the
+// sequence. Zero plays it forever, so it only makes sense for the
+// last animation. The sequence starts the animation from its first
+// frame when it gets to it, and sets its repeat count back when it
+// leaves it.
+func (seq *Sequence) Add(anim *Animation, times int) *Sequence {
+	seq.steps = append(seq.steps, sequenceStep{anim: anim, times: times})
+	if len(seq.steps) == 1 {
+		seq.start(0)
+	}
+	return seq
+}
+
+// SetOnEnd sets the callback function called when the sequence
+// has played all its animations.
+func (seq *Sequence) SetOnEnd(onEnd OnSequenceEnd) {
+	seq.onEnd = onEnd
+}
+
+// Animation returns the animation being played, or nil if the
+// sequence is empty.
+func (seq *Sequence) Animation() *Animation {
+	if len(seq.steps) == 0 {
+		return nil
+	}
+	return seq.steps[seq.index].anim
+}
+
+// Index returns the index of the animation being played.
+func (seq *Sequence) Index() int {
+	return seq.index
+}
+
+// IsEnd returns true if the sequence has played all its animations
+// or has been interrupted.
+func (seq *Sequence) IsEnd() bool {
+	return seq.ended
+}
+
+// Restart plays the sequence again from its first animation.
+func (seq *Sequence) Restart() {
+	if len(seq.steps) == 0 {
+		return
+	}
+	if !seq.ended {
+		seq.leave()
+	}
+	seq.ended = false
+	seq.start(0)
+}
+
+// Skip moves to the next animation right away.
+func (seq *Sequence) Skip() {
+	if seq.ended || len(seq.steps) == 0 {
+		return
+	}
+	seq.next()
+}
+
+// Interrupt stops the sequence: the animation being played pauses
+// where it is and the ones after it aren't played. Restart plays
+// all of them again.
+func (seq *Sequence) Interrupt() {
+	if seq.ended || len(seq.steps) == 0 {
+		return
+	}
+	seq.ended = true
+	seq.leave()
+	seq.Animation().Pause()
+}
+
+// Update updates the sequence with the delta of Animation.Update.
+func (seq *Sequence) Update() {
+	if anim := seq.Animation(); anim != nil {
+		seq.UpdateWithDelta(anim.updateDelta())
+	}
+}
+
+// UpdateWithDelta updates the animation being played with the
+// delta. When the animation ends during the update, the sequence
+// moves to the next animation and plays the rest of the delta on
+// it.
+func (seq *Sequence) UpdateWithDelta(elapsedTime time.Duration) {
+	for !seq.ended && len(seq.steps) > 0 {
+		anim := seq.Animation()
+		left := anim.timeLeftAfterComplete(elapsedTime)
+		anim.UpdateWithDelta(elapsedTime)
+		if left < 0 || !anim.IsComplete() {
+			return
+		}
+		elapsedTime = left
+		seq.next()
+	}
+}
+
+func (seq *Sequence) next() {
+	seq.leave()
+	if seq.index+1 >= len(seq.steps) {
+		seq.ended = true
+		if seq.onEnd != nil {
+			seq.onEnd(seq)
+		}
+		return
+	}
+	seq.start(seq.index + 1)
+}
+
+// start plays the animation of the step at the index the number of
+// times of the step, keeping the repeat count of the animation.
+func (seq *Sequence) start(index int) {
+	seq.index = index
+	step := seq.steps[index]
+	seq.repeat = step.anim.Repeat()
+	step.anim.SetRepeat(step.times)
+	step.anim.Restart()
+}
+
+// leave sets the repeat count of the animation being played back
+// to the one it had before the sequence started it.
+func (seq *Sequence) leave() {
+	anim := seq.Animation()
+	if anim.Repeat() != seq.repeat {
+		anim.SetRepeat(seq.repeat)
+	}
+}
+
+// Draw draws the animation being played.
+func (seq *Sequence) Draw(screen *ebiten.Image, opts *DrawOptions) {
+	if anim := seq.Animation(); anim != nil {
+		anim.Draw(screen, opts)
+	}
+}
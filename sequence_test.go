@@ -0,0 +1,107 @@
+package ganim8_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestSequence(t *testing.T) {
+	var tests = []struct {
+		name   string
+		deltas []time.Duration
+		index  int
+		pos    int
+	}{
+		{"plays the first animation", []time.Duration{time.Second}, 0, 2},
+		{"moves to the next animation", []time.Duration{time.Second * 2}, 1, 1},
+		{"carries the time left over", []time.Duration{time.Millisecond * 1500, time.Second * 2}, 1, 2},
+		{"plays the animations the number of times", []time.Duration{time.Second * 5}, 1, 1},
+		{"spans several animations in one update", []time.Duration{time.Second * 9}, 2, 2},
+		{"loops the last animation forever", []time.Duration{time.Second * 101}, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq := ganim8.NewSequence().
+				Add(ganim8.NewAnimation(mockSprite(2), time.Second), 1).
+				Add(ganim8.NewAnimation(mockSprite(3), time.Second), 2).
+				Add(ganim8.NewAnimation(mockSprite(2), time.Second), 0)
+			for _, d := range tt.deltas {
+				seq.UpdateWithDelta(d)
+			}
+			require.Equal(t, tt.index, seq.Index())
+			require.Equal(t, tt.pos, seq.Animation().Position())
+			require.False(t, seq.IsEnd())
+		})
+	}
+}
+
+func TestSequenceEnd(t *testing.T) {
+	ended := 0
+	last := ganim8.NewAnimation(mockSprite(3), time.Second)
+	seq := ganim8.NewSequence().
+		Add(ganim8.NewAnimation(mockSprite(2), time.Second), 1).
+		Add(last, 1)
+	seq.SetOnEnd(func(seq *ganim8.Sequence) { ended++ })
+	seq.UpdateWithDelta(time.Second * 100)
+	require.True(t, seq.IsEnd())
+	require.Equal(t, 1, ended)
+	require.True(t, last.IsEnd())
+	require.Equal(t, 3, last.Position())
+
+	seq.Restart()
+	require.False(t, seq.IsEnd())
+	require.Equal(t, 0, seq.Index())
+}
+
+func TestSequenceInterrupt(t *testing.T) {
+	first := ganim8.NewAnimation(mockSprite(4), time.Second)
+	seq := ganim8.NewSequence().
+		Add(first, 1).
+		Add(ganim8.NewAnimation(mockSprite(2), time.Second), 0)
+	seq.UpdateWithDelta(time.Second)
+	seq.Interrupt()
+	seq.UpdateWithDelta(time.Second * 10)
+	require.True(t, seq.IsEnd())
+	require.Equal(t, 0, seq.Index())
+	require.Equal(t, 2, first.Position())
+	require.True(t, first.Status() == ganim8.Paused)
+
+	// the animations after the interrupted one are played again
+	seq.Restart()
+	require.False(t, seq.IsEnd())
+	seq.Skip()
+	require.Equal(t, 1, seq.Index())
+}
+
+func TestSequenceKeepsRepeat(t *testing.T) {
+	first := ganim8.NewAnimation(mockSprite(2), time.Second)
+	first.SetRepeat(5)
+	second := ganim8.NewAnimation(mockSprite(2), time.Second)
+	seq := ganim8.NewSequence().Add(first, 1).Add(second, 2)
+	require.Equal(t, 1, first.Repeat())
+
+	seq.UpdateWithDelta(time.Second * 2)
+	require.Equal(t, 1, seq.Index())
+	require.Equal(t, 5, first.Repeat())
+	require.Equal(t, 2, second.Repeat())
+
+	seq.Interrupt()
+	require.Equal(t, 0, second.Repeat())
+}
+
+func TestSequenceSpeed(t *testing.T) {
+	// at this speed, the delta to the end of the first animation
+	// rounded up to a nanosecond plays a nanosecond short of it
+	first := ganim8.NewAnimation(mockSprite(1), []time.Duration{time.Nanosecond * 245490})
+	first.SetSpeed(0.7)
+	second := ganim8.NewAnimation(mockSprite(2), time.Second)
+	seq := ganim8.NewSequence().Add(first, 1).Add(second, 0)
+
+	seq.UpdateWithDelta(time.Millisecond)
+	require.Equal(t, 1, seq.Index())
+	require.True(t, first.IsEnd())
+	require.InDelta(t, time.Nanosecond*649300, second.Timer(), 1)
+}
@@ -0,0 +1,244 @@
+package ganim8
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ShapeKind is the kind of a collision shape.
+type ShapeKind int
+
+const (
+	ShapeRect ShapeKind = iota
+	ShapeCircle
+	ShapePolygon
+)
+
+// Point is a point of a polygon.
+type Point struct {
+	X, Y float64
+}
+
+// Shape is a collision shape attached to a frame, e.g. a hitbox or
+// a hurtbox, with a tag to tell them apart.
+//
+// A rectangle spans from (X, Y) to (X+W, Y+H), a circle is centered
+// on (X, Y) with the radius R and a polygon has its Points.
+type Shape struct {
+	Tag    string
+	Kind   ShapeKind
+	X, Y   float64
+	W, H   float64
+	R      float64
+	Points []Point
+}
+
+// RectShape returns a rectangle shape.
+func RectShape(tag string, x, y, w, h float64) Shape {
+	return Shape{Tag: tag, Kind: ShapeRect, X: x, Y: y, W: w, H: h}
+}
+
+// CircleShape returns a circle shape.
+func CircleShape(tag string, x, y, r float64) Shape {
+	return Shape{Tag: tag, Kind: ShapeCircle, X: x, Y: y, R: r}
+}
+
+// PolygonShape returns a polygon shape.
+func PolygonShape(tag string, points ...Point) Shape {
+	return Shape{Tag: tag, Kind: ShapePolygon, Points: points}
+}
+
+// transform writes the shape transformed by the geometry matrix
+// to dst, reusing the points of dst for polygons.
+//
+// A rectangle stays a rectangle as long as the matrix keeps it
+// aligned with the axes (flips and quarter turns) and becomes a
+// polygon otherwise. The radius of a circle is scaled by the
+// geometric mean of the scales, the square root of the absolute
+// determinant of the matrix, as an ellipse is not a shape.
+func (s *Shape) transform(g *ebiten.GeoM, dst *Shape) {
+	points := dst.Points[:0]
+	*dst = Shape{Tag: s.Tag, Kind: s.Kind}
+	a, b := g.Element(0, 0), g.Element(0, 1)
+	c, d := g.Element(1, 0), g.Element(1, 1)
+	switch s.Kind {
+	case ShapeRect:
+		x0, y0 := g.Apply(s.X, s.Y)
+		x1, y1 := g.Apply(s.X+s.W, s.Y+s.H)
+		if nearZero(b) && nearZero(c) || nearZero(a) && nearZero(d) {
+			dst.X, dst.Y = math.Min(x0, x1), math.Min(y0, y1)
+			dst.W, dst.H = math.Abs(x1-x0), math.Abs(y1-y0)
+			return
+		}
+		dst.Kind = ShapePolygon
+		for _, p := range [4]Point{{s.X, s.Y}, {s.X + s.W, s.Y}, {s.X + s.W, s.Y + s.H}, {s.X, s.Y + s.H}} {
+			x, y := g.Apply(p.X, p.Y)
+			points = append(points, Point{x, y})
+		}
+		dst.Points = points
+	case ShapeCircle:
+		dst.X, dst.Y = g.Apply(s.X, s.Y)
+		dst.R = s.R * math.Sqrt(math.Abs(a*d-b*c))
+	case ShapePolygon:
+		for _, p := range s.Points {
+			x, y := g.Apply(p.X, p.Y)
+			points = append(points, Point{x, y})
+		}
+		dst.Points = points
+	}
+}
+
+// nearZero reports whether an element of a matrix is zero but for
+// the rounding errors of math.Sincos.
+func nearZero(v float64) bool {
+	return math.Abs(v) < 1e-9
+}
+
+// AddShape attaches the shape to the frame at the index, in the
+// local coordinates of the frame (pixels from its top left corner).
+func (spr *Sprite) AddShape(index int, s Shape) {
+	if index < 0 || index >= spr.length {
+		log.Fatalf("shape %s is added to frame %d of a sprite of %d frames",
+			s.Tag, index+1, spr.length)
+	}
+	if len(spr.shapes) < spr.length {
+		shapes := make([][]Shape, spr.length)
+		copy(shapes, spr.shapes)
+		spr.shapes = shapes
+	}
+	spr.shapes[index] = append(spr.shapes[index], s)
+}
+
+// RemoveShapes removes the shapes with the tag from every frame.
+func (spr *Sprite) RemoveShapes(tag string) {
+	for i, shapes := range spr.shapes {
+		// the shapes may be shared with other sprites, e.g. the
+		// sprites of the tags of an Aseprite file
+		kept := make([]Shape, 0, len(shapes))
+		for _, s := range shapes {
+			if s.Tag != tag {
+				kept = append(kept, s)
+			}
+		}
+		spr.shapes[i] = kept
+	}
+}
+
+// LocalShapes returns the shapes of the frame at the index in the
+// local coordinates of the frame.
+func (spr *Sprite) LocalShapes(index int) []Shape {
+	if index < 0 || index >= len(spr.shapes) {
+		return nil
+	}
+	return spr.shapes[index]
+}
+
+// Shapes appends to dst the shapes of the frame at the index with
+// the tag (or all of them if the tag is empty) transformed like the
+// frame is when it is drawn with the options, and returns the
+// extended slice.
+//
+// Passing the result of the previous call sliced to zero as dst
+// reuses its memory, points of polygons included, so that querying
+// the shapes every frame doesn't allocate.
+func (spr *Sprite) Shapes(index int, tag string, opts *DrawOptions, dst []Shape) []Shape {
+	shapes := spr.LocalShapes(index)
+	if len(shapes) == 0 {
+		return dst
+	}
+	g := spr.GeoM(opts)
+	for i := range shapes {
+		if tag != "" && shapes[i].Tag != tag {
+			continue
+		}
+		if len(dst) < cap(dst) {
+			dst = dst[:len(dst)+1]
+		} else {
+			dst = append(dst, Shape{})
+		}
+		shapes[i].transform(&g, &dst[len(dst)-1])
+	}
+	return dst
+}
+
+// Shapes appends to dst the shapes of the current frame with the
+// tag (or all of them if the tag is empty) transformed like the
+// frame is when the animation is drawn with the options, tracks
+// included. See Sprite.Shapes.
+func (anim *Animation) Shapes(tag string, opts *DrawOptions, dst []Shape) []Shape {
+	return anim.sprite.Shapes(anim.frame(), tag, anim.TrackOptions(opts), dst)
+}
+
+type asepriteSlice struct {
+	Name string             `json:"name"`
+	Data string             `json:"data"`
+	Keys []asepriteSliceKey `json:"keys"`
+}
+
+type asepriteSliceKey struct {
+	Frame  int `json:"frame"`
+	Bounds struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		W int `json:"w"`
+		H int `json:"h"`
+	} `json:"bounds"`
+}
+
+// ImportAsepriteSlices adds the slices of the JSON exported by
+// Aseprite to the sprite as rectangle shapes, the frames of the
+// file being the frames of the sprite. The shapes are tagged with
+// the user data of the slices, or their name if they have none.
+//
+// Loader.Load imports the slices of the files it loads.
+func (spr *Sprite) ImportAsepriteSlices(data []byte) error {
+	var file asepriteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	shapes, err := asepriteShapes(file.Meta.Slices, spr.length)
+	if err != nil {
+		return err
+	}
+	for i, frame := range shapes {
+		for _, s := range frame {
+			spr.AddShape(i, s)
+		}
+	}
+	return nil
+}
+
+// asepriteShapes returns the shapes of each frame of the slices. A
+// key of a slice applies from its frame until the next key, and a
+// key with an empty bounds hides the slice.
+func asepriteShapes(slices []asepriteSlice, frameCount int) ([][]Shape, error) {
+	shapes := make([][]Shape, frameCount)
+	for _, slice := range slices {
+		tag := slice.Data
+		if tag == "" {
+			tag = slice.Name
+		}
+		for i, key := range slice.Keys {
+			if key.Frame < 0 || key.Frame >= frameCount {
+				return nil, fmt.Errorf("slice %q has a key on invalid frame %d", slice.Name, key.Frame)
+			}
+			end := frameCount
+			if i+1 < len(slice.Keys) {
+				end = slice.Keys[i+1].Frame
+			}
+			b := key.Bounds
+			if b.W <= 0 || b.H <= 0 {
+				continue
+			}
+			for f := key.Frame; f < end && f < frameCount; f++ {
+				shapes[f] = append(shapes[f], RectShape(tag,
+					float64(b.X), float64(b.Y), float64(b.W), float64(b.H)))
+			}
+		}
+	}
+	return shapes, nil
+}
@@ -0,0 +1,102 @@
+package ganim8_test
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockShapeSprite() *ganim8.Sprite {
+	frame := image.Rect(0, 0, 4, 2)
+	return ganim8.NewSprite(nil, []*image.Rectangle{&frame, &frame})
+}
+
+func TestShapes(t *testing.T) {
+	var tests = []struct {
+		name  string
+		shape ganim8.Shape
+		opts  *ganim8.DrawOptions
+		flipH bool
+		want  ganim8.Shape
+	}{
+		{"moves a rectangle", ganim8.RectShape("hit", 1, 0, 2, 1), ganim8.DrawOpts(10, 10), false,
+			ganim8.RectShape("hit", 11, 10, 2, 1)},
+		{"flips a rectangle", ganim8.RectShape("hit", 3, 0, 1, 1), ganim8.DrawOpts(10, 10), true,
+			ganim8.RectShape("hit", 10, 10, 1, 1)},
+		{"turns a rectangle a quarter", ganim8.RectShape("hit", 0, 0, 2, 1), ganim8.DrawOpts(10, 10, math.Pi/2), false,
+			ganim8.RectShape("hit", 9, 10, 1, 2)},
+		{"rotates a rectangle into a polygon", ganim8.RectShape("hit", 0, 0, 1, 1), ganim8.DrawOpts(0, 0, math.Pi/4), false,
+			ganim8.PolygonShape("hit", ganim8.Point{0, 0}, ganim8.Point{math.Sqrt2 / 2, math.Sqrt2 / 2},
+				ganim8.Point{0, math.Sqrt2}, ganim8.Point{-math.Sqrt2 / 2, math.Sqrt2 / 2})},
+		{"scales a circle", ganim8.CircleShape("hurt", 2, 1, 1), ganim8.DrawOpts(10, 10, 0, 2, 2, 0.5, 0.5), false,
+			ganim8.CircleShape("hurt", 10, 10, 2)},
+		{"flips a polygon", ganim8.PolygonShape("hurt", ganim8.Point{0, 0}, ganim8.Point{1, 0}, ganim8.Point{0, 2}),
+			ganim8.DrawOpts(0, 0), true,
+			ganim8.PolygonShape("hurt", ganim8.Point{4, 0}, ganim8.Point{3, 0}, ganim8.Point{4, 2})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spr := mockShapeSprite()
+			spr.AddShape(1, tt.shape)
+			spr.SetFlipH(tt.flipH)
+			anim := ganim8.NewAnimation(spr, time.Second)
+			require.Empty(t, anim.Shapes("", tt.opts, nil))
+
+			anim.UpdateWithDelta(time.Second)
+			shapes := anim.Shapes("", tt.opts, nil)
+			require.Len(t, shapes, 1)
+			got := shapes[0]
+			require.Equal(t, tt.want.Tag, got.Tag)
+			require.Equal(t, tt.want.Kind, got.Kind)
+			require.InDelta(t, tt.want.X, got.X, 1e-9)
+			require.InDelta(t, tt.want.Y, got.Y, 1e-9)
+			require.InDelta(t, tt.want.W, got.W, 1e-9)
+			require.InDelta(t, tt.want.H, got.H, 1e-9)
+			require.InDelta(t, tt.want.R, got.R, 1e-9)
+			require.Len(t, got.Points, len(tt.want.Points))
+			for i, p := range tt.want.Points {
+				require.InDelta(t, p.X, got.Points[i].X, 1e-9)
+				require.InDelta(t, p.Y, got.Points[i].Y, 1e-9)
+			}
+		})
+	}
+}
+
+func TestShapesTags(t *testing.T) {
+	spr := mockShapeSprite()
+	spr.AddShape(0, ganim8.RectShape("hit", 0, 0, 1, 1))
+	spr.AddShape(0, ganim8.CircleShape("hurt", 0, 0, 1))
+	spr.AddShape(0, ganim8.PolygonShape("hurt", ganim8.Point{0, 0}, ganim8.Point{1, 0}, ganim8.Point{0, 1}))
+	opts := ganim8.DrawOpts(0, 0, 1)
+
+	require.Len(t, spr.Shapes(0, "", opts, nil), 3)
+	require.Len(t, spr.Shapes(0, "hurt", opts, nil), 2)
+
+	dst := spr.Shapes(0, "", opts, nil)
+	allocs := testing.AllocsPerRun(10, func() {
+		dst = spr.Shapes(0, "", opts, dst[:0])
+	})
+	require.Zero(t, allocs)
+
+	spr.RemoveShapes("hurt")
+	require.Equal(t, []ganim8.Shape{ganim8.RectShape("hit", 0, 0, 1, 1)}, spr.LocalShapes(0))
+}
+
+func TestImportAsepriteSlices(t *testing.T) {
+	spr := mockShapeSprite()
+	require.NoError(t, spr.ImportAsepriteSlices([]byte(`{
+		"meta": { "slices": [ { "name": "hitbox", "data": "hit", "keys": [
+			{ "frame": 1, "bounds": { "x": 1, "y": 0, "w": 2, "h": 2 } }
+		] } ] }
+	}`)))
+	require.Empty(t, spr.LocalShapes(0))
+	require.Equal(t, []ganim8.Shape{ganim8.RectShape("hit", 1, 0, 2, 2)}, spr.LocalShapes(1))
+
+	require.Error(t, spr.ImportAsepriteSlices([]byte(`{
+		"meta": { "slices": [ { "name": "hitbox", "keys": [ { "frame": 2 } ] } ] }
+	}`)))
+}
@@ -0,0 +1,35 @@
+package ganim8
+
+import "github.com/yohamta/ganim8/v2/timeline"
+
+// Snapshot is the playback state of an animation returned by
+// Animation.Save. It is a value type, so saving and restoring it
+// doesn't allocate, e.g. for rollback netcode.
+type Snapshot struct {
+	timeline         timeline.Snapshot
+	started          bool
+	motionX, motionY float64
+	motionIndex      int
+}
+
+// Save returns the playback state of the animation, including the
+// root motion not taken yet.
+func (anim *Animation) Save() Snapshot {
+	return Snapshot{
+		timeline:    anim.timeline.Save(),
+		started:     anim.started,
+		motionX:     anim.motionX,
+		motionY:     anim.motionY,
+		motionIndex: anim.motionIndex,
+	}
+}
+
+// Restore restores the playback state saved by Save. The animation
+// plays exactly the same way after it as it did after the save.
+// No callback is called and no event is dispatched.
+func (anim *Animation) Restore(s Snapshot) {
+	anim.timeline.Restore(s.timeline)
+	anim.started = s.started
+	anim.motionX, anim.motionY = s.motionX, s.motionY
+	anim.motionIndex = s.motionIndex
+}
@@ -0,0 +1,37 @@
+package ganim8_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestSnapshot(t *testing.T) {
+	anim := ganim8.NewTickAnimation(mockSprite(4), map[string]int{"1": 3, "2-4": 2})
+	anim.SetDirection(ganim8.PingPong)
+	for i := 0; i < 5; i++ {
+		anim.Update()
+	}
+	s := anim.Save()
+
+	play := func() []int {
+		var positions []int
+		for i := 0; i < 30; i++ {
+			anim.Update()
+			positions = append(positions, anim.Position()*1000+anim.TickTimer())
+		}
+		return positions
+	}
+	want := play()
+	anim.Restore(s)
+	require.Equal(t, want, play())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s := anim.Save()
+		anim.UpdateWithDelta(time.Second)
+		anim.Restore(s)
+	})
+	require.Zero(t, allocs)
+}
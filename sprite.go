@@ -20,6 +20,7 @@ type SpriteSizeF struct {
 type Sprite struct {
 	frames             []*image.Rectangle
 	image              *ebiten.Image
+	source             image.Image
 	subImages          []*ebiten.Image
 	size               SpriteSize
 	sizeF              SpriteSizeF
@@ -27,13 +28,18 @@ type Sprite struct {
 	flippedH, flippedV bool
 	op                 *ebiten.DrawImageOptions
 	shaderOp           *ebiten.DrawRectShaderOptions
+	attachments        map[string]map[int]Attachment
+	shapes             [][]Shape
 }
 
 // NewSprite returns a new sprite.
 func NewSprite(img *ebiten.Image, frames []*image.Rectangle) *Sprite {
-	subImages := make([]*ebiten.Image, len(frames))
-	for i, frame := range frames {
-		subImages[i] = img.SubImage(*frame).(*ebiten.Image)
+	var subImages []*ebiten.Image
+	if img != nil {
+		subImages = make([]*ebiten.Image, len(frames))
+		for i, frame := range frames {
+			subImages[i] = img.SubImage(*frame).(*ebiten.Image)
+		}
 	}
 	size := SpriteSize{0, 0}
 	sizeF := SpriteSizeF{0, 0}
@@ -53,6 +59,57 @@ func NewSprite(img *ebiten.Image, frames []*image.Rectangle) *Sprite {
 	}
 }
 
+// NewSpriteFromImage returns a new sprite from the image.Image.
+//
+// The sprite keeps the image so that it can be drawn on the CPU
+// with CPURenderer. The *ebiten.Image is created only when the
+// sprite is drawn to an *ebiten.Image for the first time.
+func NewSpriteFromImage(src image.Image, frames []*image.Rectangle) *Sprite {
+	spr := NewSprite(nil, frames)
+	spr.source = src
+	return spr
+}
+
+// newSpriteWithSource returns a new sprite that can be drawn both
+// with ebiten and on the CPU.
+func newSpriteWithSource(img *ebiten.Image, src image.Image, frames []*image.Rectangle) *Sprite {
+	spr := NewSprite(img, frames)
+	spr.source = src
+	return spr
+}
+
+// Source returns the image.Image of the sprite if it has one.
+func (spr *Sprite) Source() image.Image {
+	return spr.source
+}
+
+// subImage returns the sub image of the frame, creating the
+// *ebiten.Image from the source image if needed.
+func (spr *Sprite) subImage(index int) *ebiten.Image {
+	if spr.subImages == nil {
+		if spr.image == nil {
+			spr.image = ebiten.NewImageFromImage(spr.source)
+		}
+		spr.subImages = make([]*ebiten.Image, len(spr.frames))
+		for i, frame := range spr.frames {
+			spr.subImages[i] = spr.image.SubImage(*frame).(*ebiten.Image)
+		}
+	}
+	return spr.subImages[index]
+}
+
+// setFrames replaces the image and the frames of the sprite in place.
+func (spr *Sprite) setFrames(img *ebiten.Image, src image.Image, frames []*image.Rectangle) {
+	s := newSpriteWithSource(img, src, frames)
+	spr.frames = s.frames
+	spr.image = s.image
+	spr.source = s.source
+	spr.subImages = s.subImages
+	spr.size = s.size
+	spr.sizeF = s.sizeF
+	spr.length = s.length
+}
+
 // Size returns the size of the sprite.
 func (spr *Sprite) Size() (int, int) {
 	return spr.size.W, spr.size.H
@@ -123,16 +180,32 @@ func (spr *Sprite) SetFlipV(flipV bool) {
 
 // Draw draws the current frame with the specified options.
 func (spr *Sprite) Draw(screen *ebiten.Image, index int, opts *DrawOptions) {
+	op := spr.op
+	spr.geoM(&op.GeoM, opts)
+	op.ColorM = opts.ColorM
+	op.CompositeMode = opts.CompositeMode
+
+	subImage := spr.subImage(index)
+	screen.DrawImage(subImage, op)
+}
+
+// GeoM returns the geometry matrix that transforms a frame of the
+// sprite from its local coordinates into the destination with the
+// specified options.
+func (spr *Sprite) GeoM(opts *DrawOptions) ebiten.GeoM {
+	var g ebiten.GeoM
+	spr.geoM(&g, opts)
+	return g
+}
+
+func (spr *Sprite) geoM(g *ebiten.GeoM, opts *DrawOptions) {
 	x, y := opts.X, opts.Y
 	w, h := spr.sizeF.W, spr.sizeF.H
 	r := opts.Rotate
 	ox, oy := opts.OriginX, opts.OriginY
 	sx, sy := opts.ScaleX, opts.ScaleY
 
-	op := spr.op
-	op.GeoM.Reset()
-	op.ColorM = opts.ColorM
-	op.CompositeMode = opts.CompositeMode
+	g.Reset()
 
 	if spr.flippedH {
 		sx = sx * -1
@@ -144,40 +217,36 @@ func (spr *Sprite) Draw(screen *ebiten.Image, index int, opts *DrawOptions) {
 	}
 
 	if sx != 1 || sy != 1 {
-		op.GeoM.Translate(-w*ox, -h*oy)
-		op.GeoM.Scale(sx, sy)
-		op.GeoM.Translate(w*ox, h*oy)
+		g.Translate(-w*ox, -h*oy)
+		g.Scale(sx, sy)
+		g.Translate(w*ox, h*oy)
 	}
 
 	if r != 0 {
-		op.GeoM.Translate(-w*ox, -h*oy)
-		op.GeoM.Rotate(r)
-		op.GeoM.Translate(w*ox, h*oy)
+		g.Translate(-w*ox, -h*oy)
+		g.Rotate(r)
+		g.Translate(w*ox, h*oy)
 	}
 
-	op.GeoM.Translate((x - w*ox), (y - h*oy))
-
-	subImage := spr.subImages[index]
-	screen.DrawImage(subImage, op)
+	g.Translate((x - w*ox), (y - h*oy))
 }
 
-// DrawWithShader draws the current frame with the specified options.
-func (spr *Sprite) DrawWithShader(screen *ebiten.Image, index int, opts *DrawOptions, shaderOpts *ShaderOptions) {
+// shaderGeoM sets the matrix of DrawWithShader which, unlike Draw
+// and GeoM, rotates before scaling and flips the sprite without
+// mirroring the origin.
+func (spr *Sprite) shaderGeoM(g *ebiten.GeoM, opts *DrawOptions) {
 	x, y := opts.X, opts.Y
 	w, h := spr.sizeF.W, spr.sizeF.H
 	r := opts.Rotate
 	ox, oy := opts.OriginX, opts.OriginY
 	sx, sy := opts.ScaleX, opts.ScaleY
 
-	op := spr.shaderOp
-	op.GeoM.Reset()
-	op.CompositeMode = opts.CompositeMode
-	op.Uniforms = shaderOpts.Uniforms
+	g.Reset()
 
 	if r != 0 {
-		op.GeoM.Translate(-w*ox, -h*oy)
-		op.GeoM.Rotate(r)
-		op.GeoM.Translate(w*ox, h*oy)
+		g.Translate(-w*ox, -h*oy)
+		g.Rotate(r)
+		g.Translate(w*ox, h*oy)
 	}
 
 	if spr.flippedH {
@@ -188,19 +257,27 @@ func (spr *Sprite) DrawWithShader(screen *ebiten.Image, index int, opts *DrawOpt
 	}
 
 	if sx != 1 || sy != 1 {
-		op.GeoM.Translate(-w*ox, -h*oy)
-		op.GeoM.Scale(sx, sy)
-		op.GeoM.Translate(w*ox, h*oy)
+		g.Translate(-w*ox, -h*oy)
+		g.Scale(sx, sy)
+		g.Translate(w*ox, h*oy)
 	}
 
-	op.GeoM.Translate((x - w*ox), (y - h*oy))
+	g.Translate((x - w*ox), (y - h*oy))
+}
+
+// DrawWithShader draws the current frame with the specified options.
+func (spr *Sprite) DrawWithShader(screen *ebiten.Image, index int, opts *DrawOptions, shaderOpts *ShaderOptions) {
+	op := spr.shaderOp
+	spr.shaderGeoM(&op.GeoM, opts)
+	op.CompositeMode = opts.CompositeMode
+	op.Uniforms = shaderOpts.Uniforms
 
-	subImage := spr.subImages[index]
+	subImage := spr.subImage(index)
 	op.Images[0] = subImage
 	op.Images[1] = shaderOpts.Images[0]
 	op.Images[2] = shaderOpts.Images[1]
 	op.Images[3] = shaderOpts.Images[2]
-	screen.DrawRectShader(int(w), int(h), shaderOpts.Shader, op)
+	screen.DrawRectShader(spr.size.W, spr.size.H, shaderOpts.Shader, op)
 }
 
 func (spr *Sprite) Clone() *Sprite {
@@ -0,0 +1,293 @@
+package ganim8
+
+import (
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AnyState is the state to use as the origin of transitions that
+// can be taken from every state.
+const AnyState = "*"
+
+// Condition is a condition that guards a transition of a state
+// machine, usually on its parameters.
+type Condition func(sm *StateMachine) bool
+
+// IfBool returns a condition met while the bool parameter is true.
+func IfBool(name string) Condition {
+	return func(sm *StateMachine) bool { return sm.bools[name] }
+}
+
+// IfNotBool returns a condition met while the bool parameter is
+// false.
+func IfNotBool(name string) Condition {
+	return func(sm *StateMachine) bool { return !sm.bools[name] }
+}
+
+// IfGreater returns a condition met while the float parameter is
+// greater than the value.
+func IfGreater(name string, value float64) Condition {
+	return func(sm *StateMachine) bool { return sm.floats[name] > value }
+}
+
+// IfLess returns a condition met while the float parameter is less
+// than the value.
+func IfLess(name string, value float64) Condition {
+	return func(sm *StateMachine) bool { return sm.floats[name] < value }
+}
+
+// Transition is a transition between two states of a state machine.
+type Transition struct {
+	from       string
+	to         string
+	conditions []Condition
+	trigger    string
+	exitTime   bool
+}
+
+// WithTrigger makes the transition wait for the trigger parameter
+// to be set. The trigger is reset when the transition is taken.
+func (t *Transition) WithTrigger(name string) *Transition {
+	t.trigger = name
+	return t
+}
+
+// WithExitTime makes the transition wait for the animation of the
+// state to finish its current loop (or to complete). The time left
+// in the update after the loop is played on the next state.
+func (t *Transition) WithExitTime() *Transition {
+	t.exitTime = true
+	return t
+}
+
+func (t *Transition) met(sm *StateMachine) bool {
+	if t.trigger != "" && !sm.triggers[t.trigger] {
+		return false
+	}
+	for _, c := range t.conditions {
+		if !c(sm) {
+			return false
+		}
+	}
+	return true
+}
+
+// OnStateChange is the callback function called when a state
+// machine changes its state.
+type OnStateChange func(sm *StateMachine, from, to string)
+
+// StateMachine plays the animation of its current state and moves
+// between states with transitions guarded by conditions on its
+// parameters, e.g. idle, walk and attack animations of a character.
+type StateMachine struct {
+	states      map[string]*Animation
+	transitions []*Transition
+	current     string
+	bools       map[string]bool
+	floats      map[string]float64
+	triggers    map[string]bool
+	onChange    OnStateChange
+}
+
+// NewStateMachine returns a new state machine without states.
+func NewStateMachine() *StateMachine {
+	return &StateMachine{
+		states:   map[string]*Animation{},
+		bools:    map[string]bool{},
+		floats:   map[string]float64{},
+		triggers: map[string]bool{},
+	}
+}
+
+// AddState adds the state playing the animation. The first state
+// added is the initial state.
+func (sm *StateMachine) AddState(name string, anim *Animation) {
+	if name == AnyState {
+		log.Fatalf("state can't be named %s", AnyState)
+	}
+	sm.states[name] = anim
+	if sm.current == "" {
+		sm.current = name
+	}
+}
+
+// AddTransition adds the transition from a state (or AnyState) to
+// another taken when all the conditions are met. Transitions are
+// checked in the order they were added, the ones from AnyState
+// first.
+func (sm *StateMachine) AddTransition(from, to string, conditions ...Condition) *Transition {
+	if _, ok := sm.states[from]; !ok && from != AnyState {
+		log.Fatalf("state %s is not added", from)
+	}
+	if _, ok := sm.states[to]; !ok {
+		log.Fatalf("state %s is not added", to)
+	}
+	t := &Transition{from: from, to: to, conditions: conditions}
+	sm.transitions = append(sm.transitions, t)
+	return t
+}
+
+// SetOnStateChange sets the callback function called when the
+// state changes.
+func (sm *StateMachine) SetOnStateChange(onChange OnStateChange) {
+	sm.onChange = onChange
+}
+
+// State returns the name of the current state.
+func (sm *StateMachine) State() string {
+	return sm.current
+}
+
+// Animation returns the animation of the current state, or nil if
+// no state has been added.
+func (sm *StateMachine) Animation() *Animation {
+	return sm.states[sm.current]
+}
+
+// SetState moves to the state and restarts its animation without
+// checking the transitions.
+func (sm *StateMachine) SetState(name string) {
+	if _, ok := sm.states[name]; !ok {
+		log.Fatalf("state %s is not added", name)
+	}
+	sm.enter(name)
+}
+
+// SetBool sets the bool parameter.
+func (sm *StateMachine) SetBool(name string, value bool) {
+	sm.bools[name] = value
+}
+
+// Bool returns the bool parameter.
+func (sm *StateMachine) Bool(name string) bool {
+	return sm.bools[name]
+}
+
+// SetFloat sets the float parameter.
+func (sm *StateMachine) SetFloat(name string, value float64) {
+	sm.floats[name] = value
+}
+
+// Float returns the float parameter.
+func (sm *StateMachine) Float(name string) float64 {
+	return sm.floats[name]
+}
+
+// SetTrigger sets the trigger parameter until a transition waiting
+// for it is taken.
+func (sm *StateMachine) SetTrigger(name string) {
+	sm.triggers[name] = true
+}
+
+// ResetTrigger resets the trigger parameter.
+func (sm *StateMachine) ResetTrigger(name string) {
+	delete(sm.triggers, name)
+}
+
+// Update updates the state machine with the delta of
+// Animation.Update. It does nothing if no state has been added.
+func (sm *StateMachine) Update() {
+	if sm.current == "" {
+		return
+	}
+	sm.UpdateWithDelta(sm.Animation().updateDelta())
+}
+
+// UpdateWithDelta takes the first transition whose conditions are
+// met and updates the animation of the current state with the
+// delta. At most one transition is taken per update, and the ones
+// without exit time are taken before the ones with exit time.
+// It does nothing if no state has been added.
+//
+// A transition with exit time is taken when the animation finishes
+// its loop during the update: the animation plays until the end of
+// the loop and the next one plays the rest of the delta.
+func (sm *StateMachine) UpdateWithDelta(elapsedTime time.Duration) {
+	if sm.current == "" {
+		return
+	}
+	t, exit := sm.find()
+	if t != nil {
+		sm.take(t)
+		sm.Animation().UpdateWithDelta(elapsedTime)
+		return
+	}
+	anim := sm.Animation()
+	if exit != nil {
+		if anim.IsComplete() || anim.IsEnd() {
+			sm.take(exit)
+			sm.Animation().UpdateWithDelta(elapsedTime)
+			return
+		}
+		if left := anim.timeToLoopEnd(); left >= 0 && elapsedTime >= left {
+			anim.UpdateWithDelta(left)
+			sm.take(exit)
+			sm.Animation().UpdateWithDelta(elapsedTime - left)
+			return
+		}
+	}
+	anim.UpdateWithDelta(elapsedTime)
+}
+
+// find returns the first transition without exit time and the
+// first one with exit time whose conditions are met, checking the
+// transitions from AnyState first. Transitions from AnyState to the
+// current state are left out so that they don't restart it.
+func (sm *StateMachine) find() (*Transition, *Transition) {
+	t, exit := sm.findFrom(true, nil)
+	if t != nil {
+		return t, exit
+	}
+	return sm.findFrom(false, exit)
+}
+
+// findFrom looks for the transitions of find from AnyState or from
+// the current state, keeping the transition with exit time found
+// before if any.
+func (sm *StateMachine) findFrom(anyState bool, exit *Transition) (*Transition, *Transition) {
+	for _, t := range sm.transitions {
+		if anyState != (t.from == AnyState) {
+			continue
+		}
+		if anyState && t.to == sm.current || !anyState && t.from != sm.current {
+			continue
+		}
+		if !t.met(sm) {
+			continue
+		}
+		if !t.exitTime {
+			return t, exit
+		}
+		if exit == nil {
+			exit = t
+		}
+	}
+	return nil, exit
+}
+
+func (sm *StateMachine) take(t *Transition) {
+	if t.trigger != "" {
+		delete(sm.triggers, t.trigger)
+	}
+	sm.enter(t.to)
+}
+
+func (sm *StateMachine) enter(name string) {
+	from := sm.current
+	sm.current = name
+	sm.states[name].Restart()
+	if sm.onChange != nil {
+		sm.onChange(sm, from, name)
+	}
+}
+
+// Draw draws the animation of the current state. It draws nothing
+// if no state has been added.
+func (sm *StateMachine) Draw(screen *ebiten.Image, opts *DrawOptions) {
+	if sm.current == "" {
+		return
+	}
+	sm.Animation().Draw(screen, opts)
+}
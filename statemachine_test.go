@@ -0,0 +1,96 @@
+package ganim8_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func mockStateMachine() *ganim8.StateMachine {
+	sm := ganim8.NewStateMachine()
+	sm.AddState("idle", ganim8.NewAnimation(mockSprite(2), time.Second))
+	sm.AddState("walk", ganim8.NewAnimation(mockSprite(4), time.Second))
+	sm.AddState("attack", ganim8.NewAnimation(mockSprite(3), time.Second))
+	sm.AddState("hurt", ganim8.NewAnimation(mockSprite(1), time.Second))
+	sm.AddTransition("idle", "walk", ganim8.IfGreater("speed", 0))
+	sm.AddTransition("walk", "idle", ganim8.IfLess("speed", 0.1)).WithExitTime()
+	sm.AddTransition("idle", "attack").WithTrigger("attack")
+	sm.AddTransition("attack", "idle").WithExitTime()
+	sm.AddTransition(ganim8.AnyState, "hurt", ganim8.IfBool("hurt"))
+	sm.AddTransition("hurt", "idle", ganim8.IfNotBool("hurt"))
+	return sm
+}
+
+func TestStateMachine(t *testing.T) {
+	type step struct {
+		set   func(sm *ganim8.StateMachine)
+		delta time.Duration
+		state string
+		pos   int
+	}
+	var tests = []struct {
+		name  string
+		steps []step
+	}{
+		{"starts in the first state", []step{
+			{nil, time.Second, "idle", 2},
+		}},
+		{"takes transitions on parameters", []step{
+			{func(sm *ganim8.StateMachine) { sm.SetFloat("speed", 1) }, time.Second, "walk", 2},
+		}},
+		{"waits for the loop to end on exit time", []step{
+			{func(sm *ganim8.StateMachine) { sm.SetFloat("speed", 1) }, time.Second * 2, "walk", 3},
+			{func(sm *ganim8.StateMachine) { sm.SetFloat("speed", 0) }, time.Second, "walk", 4},
+			{nil, time.Millisecond * 1500, "idle", 1},
+			{nil, time.Millisecond * 500, "idle", 2},
+		}},
+		{"consumes triggers", []step{
+			{func(sm *ganim8.StateMachine) { sm.SetTrigger("attack") }, 0, "attack", 1},
+			{nil, time.Second * 4, "idle", 2},
+			{nil, time.Second, "idle", 1},
+		}},
+		{"takes transitions from any state", []step{
+			{func(sm *ganim8.StateMachine) { sm.SetFloat("speed", 1) }, 0, "walk", 1},
+			{func(sm *ganim8.StateMachine) { sm.SetBool("hurt", true) }, 0, "hurt", 1},
+			{nil, time.Second, "hurt", 1},
+			{func(sm *ganim8.StateMachine) { sm.SetBool("hurt", false) }, 0, "idle", 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := mockStateMachine()
+			for i, s := range tt.steps {
+				if s.set != nil {
+					s.set(sm)
+				}
+				sm.UpdateWithDelta(s.delta)
+				require.Equal(t, s.state, sm.State(), "step %d", i)
+				require.Equal(t, s.pos, sm.Animation().Position(), "step %d", i)
+			}
+		})
+	}
+}
+
+func TestStateMachineOnStateChange(t *testing.T) {
+	sm := mockStateMachine()
+	changes := []string{}
+	sm.SetOnStateChange(func(sm *ganim8.StateMachine, from, to string) {
+		changes = append(changes, from+">"+to)
+	})
+	sm.SetFloat("speed", 1)
+	sm.UpdateWithDelta(0)
+	sm.SetState("attack")
+	require.Equal(t, []string{"idle>walk", "walk>attack"}, changes)
+}
+
+func TestStateMachineWithoutStates(t *testing.T) {
+	sm := ganim8.NewStateMachine()
+	require.NotPanics(t, func() {
+		sm.Update()
+		sm.UpdateWithDelta(time.Second)
+		sm.Draw(mockImg, ganim8.DrawOpts(0, 0))
+	})
+	require.Nil(t, sm.Animation())
+}
@@ -0,0 +1,153 @@
+package timeline
+
+import "time"
+
+// Def is the immutable definition of the frames of a timeline:
+// their durations and the direction in which they are played. A
+// Def can be shared by any number of players.
+type Def struct {
+	durations     []time.Duration
+	intervals     []time.Duration
+	totalDuration time.Duration
+	cycleDuration time.Duration
+	direction     Direction
+}
+
+// NewDef returns a new definition of frames with the durations
+// played in the direction. The durations must not be modified
+// afterwards.
+func NewDef(durations []time.Duration, direction Direction) *Def {
+	def := &Def{durations: durations, direction: direction}
+	def.intervals, def.totalDuration = parseIntervals(durations)
+	def.cycleDuration = def.cycle()
+	return def
+}
+
+// ParseDef returns a new definition of frameCount frames played in
+// the direction. durations are the same as ParseDurations.
+func ParseDef(durations interface{}, frameCount int, direction Direction) (*Def, error) {
+	_durations, err := ParseDurations(durations, frameCount)
+	if err != nil {
+		return nil, err
+	}
+	return NewDef(_durations, direction), nil
+}
+
+// WithDirection returns a definition of the same frames played in
+// the direction.
+func (def *Def) WithDirection(direction Direction) *Def {
+	if direction == def.direction {
+		return def
+	}
+	new := *def
+	new.direction = direction
+	new.cycleDuration = new.cycle()
+	return &new
+}
+
+// Direction returns the direction in which the frames are played.
+func (def *Def) Direction() Direction {
+	return def.direction
+}
+
+// Length returns the number of frames.
+func (def *Def) Length() int {
+	return len(def.durations)
+}
+
+// Durations returns the durations of each frames.
+func (def *Def) Durations() []time.Duration {
+	return def.durations
+}
+
+// TotalDuration returns the total duration of the frames.
+func (def *Def) TotalDuration() time.Duration {
+	return def.totalDuration
+}
+
+// Cycle returns the time it takes to play all the frames once in
+// the direction.
+func (def *Def) Cycle() time.Duration {
+	return def.cycleDuration
+}
+
+// cycle returns the time it takes to play all the frames once in
+// the direction of the definition.
+func (def *Def) cycle() time.Duration {
+	n := len(def.durations)
+	if n > 2 && (def.direction == PingPong || def.direction == PingPongReverse) {
+		return def.totalDuration*2 - def.durations[0] - def.durations[n-1]
+	}
+	return def.totalDuration
+}
+
+// seek returns the index of the frame shown at the timer and the
+// time at which the frame started in the cycle.
+func (def *Def) seek(timer time.Duration) (int, time.Duration) {
+	total := def.totalDuration
+	switch def.direction {
+	case Reverse:
+		return def.seekBackward(timer, total, 0)
+	case PingPong:
+		if timer < total {
+			return def.seekForward(timer, 0, 0)
+		}
+		return def.seekBackward(timer-total, total-def.durations[len(def.durations)-1], total)
+	case PingPongReverse:
+		if timer < total {
+			return def.seekBackward(timer, total, 0)
+		}
+		return def.seekForward(timer-total, def.durations[0], total)
+	}
+	return def.seekForward(timer, 0, 0)
+}
+
+// seekForward seeks the frame played forward from the time from,
+// where the playback started at offset in the cycle.
+func (def *Def) seekForward(timer, from, offset time.Duration) (int, time.Duration) {
+	i := seekFrameIndex(def.intervals, from+timer)
+	return i, offset + def.intervals[i] - from
+}
+
+// seekBackward seeks the frame played backward from the time from,
+// where the playback started at offset in the cycle.
+func (def *Def) seekBackward(timer, from, offset time.Duration) (int, time.Duration) {
+	i := seekFrameIndex(def.intervals, from-timer-1)
+	return i, offset + from - def.intervals[i+1]
+}
+
+// seekUnwrapped is seek for a timer out of the cycle: the start of
+// the frame is returned in the same loop as the timer.
+func (def *Def) seekUnwrapped(timer time.Duration) (int, time.Duration) {
+	base := floorDiv(timer, def.cycleDuration) * def.cycleDuration
+	index, start := def.seek(timer - base)
+	return index, base + start
+}
+
+// frameStart returns the time at which the frame is shown for the
+// first time in the cycle.
+func (def *Def) frameStart(index int) time.Duration {
+	switch def.direction {
+	case Reverse, PingPongReverse:
+		return def.totalDuration - def.intervals[index+1]
+	}
+	return def.intervals[index]
+}
+
+// endIndex returns the index of the last frame played in the cycle.
+func (def *Def) endIndex() int {
+	if def.cycleDuration <= 0 {
+		return len(def.durations) - 1
+	}
+	i, _ := def.seek(def.cycleDuration - 1)
+	return i
+}
+
+// startIndex returns the index of the first frame played in the cycle.
+func (def *Def) startIndex() int {
+	if def.cycleDuration <= 0 {
+		return 0
+	}
+	i, _ := def.seek(0)
+	return i
+}
@@ -0,0 +1,113 @@
+package timeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// OnEvent is the callback function called when a timeline enters
+// a frame with events. It has three parameters: the timeline, the
+// name of the event and the position of the frame (from 1).
+type OnEvent func(tl *Timeline, event string, position int)
+
+// OnEnter is the callback function called when a timeline enters
+// a frame. It has two parameters: the timeline and the position of
+// the frame (from 1).
+type OnEnter func(tl *Timeline, position int)
+
+// SetOnEvent sets the callback function called for the events of
+// the frames entered during UpdateWithDelta.
+func (tl *Timeline) SetOnEvent(onEvent OnEvent) {
+	tl.onEvent = onEvent
+}
+
+// SetOnEnter sets the callback function called for every frame
+// entered during UpdateWithDelta, whether it has events or not.
+func (tl *Timeline) SetOnEnter(onEnter OnEnter) {
+	tl.onEnter = onEnter
+}
+
+// AddEvent attaches the named event to frames. frames is a frame
+// number (from 1) or a range of frames like "2-4".
+func (tl *Timeline) AddEvent(name string, frames interface{}) error {
+	min, max, step, err := ParseInterval(frames)
+	if err != nil {
+		return err
+	}
+	for i := min; i != max+step; i += step {
+		if i < 1 || i > len(tl.player.def.durations) {
+			return fmt.Errorf("failed to add event %s: there is no frame %d", name, i)
+		}
+	}
+	if tl.events == nil {
+		tl.events = map[int][]string{}
+	}
+	for i := min; i != max+step; i += step {
+		tl.events[i-1] = append(tl.events[i-1], name)
+	}
+	return nil
+}
+
+// RemoveEvent removes the named event from every frame.
+func (tl *Timeline) RemoveEvent(name string) {
+	for index, names := range tl.events {
+		kept := names[:0]
+		for _, n := range names {
+			if n != name {
+				kept = append(kept, n)
+			}
+		}
+		if len(kept) == 0 {
+			delete(tl.events, index)
+		} else {
+			tl.events[index] = kept
+		}
+	}
+}
+
+// Events returns the names of the events of the frame at the
+// position (from 1) in the order they were added.
+func (tl *Timeline) Events(position int) []string {
+	return tl.events[position-1]
+}
+
+// fireEvents moves the timer by the delta one frame at a time and
+// calls the onEnter callback and the onEvent callback for every
+// frame entered, in the order the frames are entered. It stops when
+// the timeline completes or a callback pauses it or moves it.
+func (tl *Timeline) fireEvents(delta time.Duration) {
+	for {
+		// the time to the start of the next frame entered in the
+		// direction of the delta
+		index, start := tl.player.def.seek(tl.player.timer)
+		step := start + tl.player.def.durations[index] - tl.player.timer
+		if delta < 0 {
+			step = start - 1 - tl.player.timer
+		}
+		if delta >= 0 && step > delta || delta < 0 && step < delta {
+			tl.advance(delta)
+			return
+		}
+		if !tl.advance(step) {
+			return
+		}
+		delta -= step
+		position, timer := tl.player.position, tl.player.timer
+		tl.fire(position)
+		if tl.player.status != Playing || tl.player.position != position || tl.player.timer != timer {
+			return
+		}
+	}
+}
+
+func (tl *Timeline) fire(index int) {
+	if tl.onEnter != nil {
+		tl.onEnter(tl, index+1)
+	}
+	if tl.onEvent == nil {
+		return
+	}
+	for _, name := range tl.events[index] {
+		tl.onEvent(tl, name, index+1)
+	}
+}
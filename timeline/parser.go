@@ -0,0 +1,203 @@
+package timeline
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var intervalMatcher = regexp.MustCompile("^([0-9]+)-([0-9]+)$")
+
+// ParseInterval parses a frame number or a range of frames like
+// "1-5" or "5-1" and returns the first and the last frame with the
+// step to iterate over them.
+func ParseInterval(val interface{}) (int, int, int, error) {
+	switch v := val.(type) {
+	case int:
+		return v, v, 1, nil
+	case float64:
+		return int(v), int(v), 1, nil
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n, n, 1, nil
+		}
+		matches := intervalMatcher.FindStringSubmatch(strings.TrimSpace(v))
+		if len(matches) != 3 {
+			return 0, 0, 0, fmt.Errorf("Could not parse interval from %s", v)
+		}
+		min, _ := strconv.Atoi(matches[1])
+		max, _ := strconv.Atoi(matches[2])
+		if min > max {
+			return min, max, -1, nil
+		} else {
+			return min, max, 1, nil
+		}
+	default:
+		return 0, 0, 0, fmt.Errorf("Could not parse interval from %v", val)
+	}
+}
+
+// ParseDurations parses the durations of frameCount frames.
+//
+// durations is a time.Duration or a []time.Duration or
+// a map[string]time.Duration. Numbers are read as milliseconds
+// and can be used in place of time.Duration, also as []int,
+// map[string]int, []interface{} and map[string]interface{}.
+func ParseDurations(durations interface{}, frameCount int) ([]time.Duration, error) {
+	return parseDurations(durations, frameCount, time.Millisecond, false)
+}
+
+// ParseTickDurations parses the durations of frameCount frames
+// given in ticks of the specified length.
+//
+// ticks is an int or a []int or a map[string]int. The durations
+// are exact multiples of the tick so that a timeline updated with
+// whole ticks always lands on the same frames: numbers that aren't
+// whole and durations that aren't multiples of the tick are
+// rejected.
+func ParseTickDurations(ticks interface{}, frameCount int, tick time.Duration) ([]time.Duration, error) {
+	return parseDurations(ticks, frameCount, tick, true)
+}
+
+// parseDurations parses the durations reading numbers in the unit.
+// With ticks, the numbers must be whole.
+func parseDurations(durations interface{}, frameCount int, unit time.Duration, ticks bool) ([]time.Duration, error) {
+	result := make([]time.Duration, frameCount)
+	set := func(i int, d time.Duration) error {
+		if i < 0 || i >= frameCount {
+			return fmt.Errorf("failed to parse durations: there is no frame %d", i+1)
+		}
+		result[i] = d
+		return nil
+	}
+	switch val := durations.(type) {
+	case time.Duration:
+		for i := 0; i < frameCount; i++ {
+			result[i] = val
+		}
+	case []time.Duration:
+		for i := range val {
+			if err := set(i, val[i]); err != nil {
+				return nil, err
+			}
+		}
+	case []int:
+		for i := range val {
+			if err := set(i, unit*time.Duration(val[i])); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i := range val {
+			d, err := parseDurationValue(val[i], unit, ticks)
+			if err != nil {
+				return nil, err
+			}
+			if err := set(i, d); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]time.Duration:
+		for key, duration := range val {
+			min, max, step, err := ParseInterval(key)
+			if err != nil {
+				return nil, err
+			}
+			for i := min; i <= max; i += step {
+				if err := set(i-1, duration); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case map[string]int:
+		for key, n := range val {
+			min, max, step, err := ParseInterval(key)
+			if err != nil {
+				return nil, err
+			}
+			for i := min; i <= max; i += step {
+				if err := set(i-1, unit*time.Duration(n)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case map[string]interface{}:
+		for key, duration := range val {
+			min, max, step, err := ParseInterval(key)
+			if err != nil {
+				return nil, err
+			}
+			d, err := parseDurationValue(duration, unit, ticks)
+			if err != nil {
+				return nil, err
+			}
+			for i := min; i <= max; i += step {
+				if err := set(i-1, d); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case interface{}:
+		d, err := parseDurationValue(val, unit, ticks)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < frameCount; i++ {
+			result[i] = d
+		}
+	default:
+		return nil, fmt.Errorf("failed to parse durations: type=%T val=%+v", durations, durations)
+	}
+	if ticks {
+		for i, d := range result {
+			if d%unit != 0 {
+				return nil, fmt.Errorf("failed to parse durations: %v of frame %d is not a whole number of ticks", d, i+1)
+			}
+		}
+	}
+	return result, nil
+}
+
+func parseDurationValue(value interface{}, unit time.Duration, ticks bool) (time.Duration, error) {
+	switch val := value.(type) {
+	case time.Duration:
+		return val, nil
+	case int:
+		return unit * time.Duration(val), nil
+	case float64:
+		if ticks && val != math.Trunc(val) {
+			return 0, fmt.Errorf("failed to parse duration value: %v is not a whole number of ticks", val)
+		}
+		return unit * time.Duration(val), nil
+	default:
+		return 0, fmt.Errorf("failed to parse duration value: %+v", value)
+	}
+}
+
+func parseIntervals(durations []time.Duration) ([]time.Duration, time.Duration) {
+	result := []time.Duration{0}
+	var time time.Duration = 0
+	for _, v := range durations {
+		time += v
+		result = append(result, time)
+	}
+	return result, time
+}
+
+func seekFrameIndex(intervals []time.Duration, timer time.Duration) int {
+	high, low, i := len(intervals)-2, 0, 0
+	for low <= high {
+		i = (low + high) / 2
+		if timer >= intervals[i+1] {
+			low = i + 1
+		} else if timer < intervals[i] {
+			high = i - 1
+		} else {
+			return i
+		}
+	}
+	return i
+}
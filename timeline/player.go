@@ -0,0 +1,197 @@
+package timeline
+
+import "time"
+
+// Player is the playback state of a definition of frames: which
+// frame is shown, the time elapsed in the cycle and the status.
+//
+// It is a small value type: creating, copying and updating a
+// player doesn't allocate, so thousands of them can share one Def.
+// A Player loops forever and has no callbacks; Timeline adds them.
+type Player struct {
+	def      *Def
+	position int
+	timer    time.Duration
+	status   Status
+}
+
+// NewPlayer returns a player of the definition playing from the
+// first frame played in its direction.
+func NewPlayer(def *Def) Player {
+	return Player{def: def, position: def.startIndex(), status: Playing}
+}
+
+// Def returns the definition of the frames played.
+func (p *Player) Def() *Def {
+	return p.def
+}
+
+// Direction returns the direction in which the frames are played.
+func (p *Player) Direction() Direction {
+	return p.def.direction
+}
+
+// Length returns the number of frames.
+func (p *Player) Length() int {
+	return len(p.def.durations)
+}
+
+// Durations returns the durations of each frames.
+func (p *Player) Durations() []time.Duration {
+	return p.def.durations
+}
+
+// TotalDuration returns the total duration of the frames.
+func (p *Player) TotalDuration() time.Duration {
+	return p.def.totalDuration
+}
+
+// Cycle returns the time it takes to play all the frames once in
+// the direction.
+func (p *Player) Cycle() time.Duration {
+	return p.def.cycleDuration
+}
+
+// UpdateWithDelta updates the player with the specified delta and
+// returns how many times it looped, negative when it is played
+// backward.
+func (p *Player) UpdateWithDelta(elapsedTime time.Duration) int {
+	if p.status != Playing || p.def.cycleDuration <= 0 {
+		return 0
+	}
+	p.timer += elapsedTime
+	loops := int(floorDiv(p.timer, p.def.cycleDuration))
+	p.timer -= p.def.cycleDuration * time.Duration(loops)
+	p.position, _ = p.def.seek(p.timer)
+	return loops
+}
+
+// Restart moves the player to its first frame and plays it.
+func (p *Player) Restart() {
+	p.position = p.def.startIndex()
+	p.timer = 0
+	p.status = Playing
+}
+
+// ReplaceDef replaces the definition played by the player keeping
+// the current frame and the time elapsed in it where possible.
+func (p *Player) ReplaceDef(def *Def) {
+	position := p.position
+	_, start := p.def.seek(p.timer)
+	elapsed := p.timer - start
+	p.def = def
+	if position >= len(def.durations) {
+		position, elapsed = len(def.durations)-1, 0
+	}
+	if elapsed < 0 || elapsed >= def.durations[position] {
+		elapsed = 0
+	}
+	p.position = position
+	p.timer = def.frameStart(position) + elapsed
+}
+
+// Status returns the status of the player.
+func (p *Player) Status() Status {
+	return p.status
+}
+
+// Pause pauses the player.
+func (p *Player) Pause() {
+	p.status = Paused
+}
+
+// Resume resumes the player.
+func (p *Player) Resume() {
+	p.status = Playing
+}
+
+// Position returns the current position of the frame.
+// The position counts from 1 (not 0).
+func (p *Player) Position() int {
+	return p.position + 1
+}
+
+// Index returns the index of the current frame.
+// The index counts from 0.
+func (p *Player) Index() int {
+	return p.position
+}
+
+// Timer returns the current accumulated times of current frame.
+func (p *Player) Timer() time.Duration {
+	return p.timer
+}
+
+// IsEnd returns true if the player is paused on the last frame
+// played in its direction.
+func (p *Player) IsEnd() bool {
+	return p.status == Paused && p.position == p.def.endIndex()
+}
+
+// SetTime moves the player to the time in its cycle, clamped
+// between zero and the time it takes to play all the frames once.
+// No callback is called.
+func (p *Player) SetTime(t time.Duration) {
+	if t < 0 {
+		t = 0
+	}
+	if t > p.def.cycleDuration {
+		t = p.def.cycleDuration
+	}
+	p.timer = t
+	if t == p.def.cycleDuration && t > 0 {
+		// the end of the cycle shows the last frame like PauseAtEnd
+		t--
+	}
+	p.position, _ = p.def.seek(t)
+}
+
+// Progress returns how much of the cycle has been played, from 0
+// to 1.
+func (p *Player) Progress() float64 {
+	if p.def.cycleDuration <= 0 {
+		return 0
+	}
+	return float64(p.timer) / float64(p.def.cycleDuration)
+}
+
+// SetProgress moves the player to the progress of its cycle,
+// from 0 to 1.
+func (p *Player) SetProgress(progress float64) {
+	p.SetTime(time.Duration(progress * float64(p.def.cycleDuration)))
+}
+
+// RemainingFrameTime returns the time left before the player
+// leaves the current frame.
+func (p *Player) RemainingFrameTime() time.Duration {
+	if p.timer >= p.def.cycleDuration {
+		return 0
+	}
+	index, start := p.def.seek(p.timer)
+	return start + p.def.durations[index] - p.timer
+}
+
+// GoToFrame sets the position of the player and
+// sets the timer at the start of the frame.
+// With the ping-pong directions, the timer is set at the first
+// time the frame is shown in the cycle.
+func (p *Player) GoToFrame(position int) {
+	p.position = position - 1
+	p.timer = p.def.frameStart(p.position)
+}
+
+// PauseAtEnd pauses the player and set the position
+// to the last frame played in its direction.
+func (p *Player) PauseAtEnd() {
+	p.position = p.def.endIndex()
+	p.timer = p.def.cycleDuration
+	p.Pause()
+}
+
+// PauseAtStart pauses the player and set the position
+// to the first frame played in its direction.
+func (p *Player) PauseAtStart() {
+	p.position = p.def.startIndex()
+	p.timer = 0
+	p.status = Paused
+}
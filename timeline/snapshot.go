@@ -0,0 +1,42 @@
+package timeline
+
+import "time"
+
+// Snapshot is the playback state of a timeline. It is a value
+// type, so saving and restoring it doesn't allocate, e.g. to roll
+// back the state of a game hundreds of times per second.
+type Snapshot struct {
+	Position  int
+	Timer     time.Duration
+	Status    Status
+	Direction Direction
+	Speed     float64
+	Repeat    int
+	Loops     int
+}
+
+// Save returns the playback state of the timeline.
+func (tl *Timeline) Save() Snapshot {
+	return Snapshot{
+		Position:  tl.player.position,
+		Timer:     tl.player.timer,
+		Status:    tl.player.status,
+		Direction: tl.player.def.direction,
+		Speed:     tl.speed,
+		Repeat:    tl.repeat,
+		Loops:     tl.loops,
+	}
+}
+
+// Restore restores the playback state saved by Save. The timeline
+// plays exactly the same way after it as it did after the save.
+// No callback is called.
+func (tl *Timeline) Restore(s Snapshot) {
+	tl.player.def = tl.player.def.WithDirection(s.Direction)
+	tl.player.position = s.Position
+	tl.player.timer = s.Timer
+	tl.player.status = s.Status
+	tl.speed = s.Speed
+	tl.repeat = s.Repeat
+	tl.loops = s.Loops
+}
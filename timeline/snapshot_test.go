@@ -0,0 +1,43 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+func TestSnapshot(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(2), d(3)})
+	tl.SetRepeat(5)
+	tl.SetSpeed(1.5)
+	tl.UpdateWithDelta(time.Millisecond * 3700)
+	s := tl.Save()
+
+	play := func() []timeline.Snapshot {
+		var states []timeline.Snapshot
+		for i := 0; i < 20; i++ {
+			tl.UpdateWithDelta(time.Millisecond * 1300)
+			states = append(states, tl.Save())
+		}
+		return states
+	}
+	want := play()
+
+	tl.SetDirection(timeline.PingPong)
+	tl.PauseAtStart()
+	tl.Restore(s)
+	require.Equal(t, timeline.Forward, tl.Direction())
+	require.Equal(t, want, play())
+}
+
+func TestSnapshotAllocs(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(2), d(3)})
+	allocs := testing.AllocsPerRun(100, func() {
+		s := tl.Save()
+		tl.UpdateWithDelta(d(1))
+		tl.Restore(s)
+	})
+	require.Zero(t, allocs)
+}
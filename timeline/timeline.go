@@ -0,0 +1,451 @@
+// Package timeline implements the timing of ganim8 animations
+// without any graphics dependency.
+//
+// A Timeline knows which frame is shown at any time from the
+// durations of the frames, so it can be used on a dedicated
+// server to simulate animations (e.g. to know when an attack
+// frame lands) without linking ebiten.
+package timeline
+
+import (
+	"time"
+)
+
+// Status represents the status of a timeline.
+type Status int
+
+const (
+	Playing Status = iota
+	Paused
+)
+
+// Direction represents the direction in which the frames of a
+// timeline are played.
+type Direction int
+
+const (
+	// Forward plays the frames from the first to the last.
+	Forward Direction = iota
+	// Reverse plays the frames from the last to the first.
+	Reverse
+	// PingPong plays the frames from the first to the last and
+	// then back, without repeating the first and the last frame.
+	PingPong
+	// PingPongReverse plays the frames from the last to the first
+	// and then back, without repeating the first and the last frame.
+	PingPongReverse
+)
+
+// OnLoop is the callback function called every time a timeline
+// "loops". It has two parameters: the timeline and how many loops
+// have been elapsed.
+type OnLoop func(tl *Timeline, loops int)
+
+// Nop does nothing.
+func Nop(tl *Timeline, loops int) {}
+
+// OnComplete is the callback function called when a timeline has
+// played as many times as its repeat count.
+type OnComplete func(tl *Timeline)
+
+// Timeline represents the timing of an animation: which frame is
+// shown and how much time has been elapsed. It plays its frames
+// with a Player and adds a speed, a repeat count, events and
+// callbacks on top of it.
+type Timeline struct {
+	player     Player
+	onLoop     OnLoop
+	repeat     int
+	loops      int
+	onComplete OnComplete
+	speed      float64
+	events     map[int][]string
+	onEvent    OnEvent
+	onEnter    OnEnter
+	tick       time.Duration
+}
+
+// New returns a new timeline with the durations of each frame.
+func New(durations []time.Duration, onLoop ...OnLoop) *Timeline {
+	return NewFromDef(NewDef(durations, Forward), onLoop...)
+}
+
+// NewFromDef returns a new timeline playing the definition, which
+// is shared rather than copied.
+func NewFromDef(def *Def, onLoop ...OnLoop) *Timeline {
+	ol := Nop
+	if len(onLoop) > 0 {
+		ol = onLoop[0]
+	}
+	return &Timeline{
+		player: NewPlayer(def),
+		onLoop: ol,
+		speed:  1,
+	}
+}
+
+// Parse returns a new timeline of frameCount frames.
+// durations are the same as ParseDurations.
+func Parse(durations interface{}, frameCount int, onLoop ...OnLoop) (*Timeline, error) {
+	_durations, err := ParseDurations(durations, frameCount)
+	if err != nil {
+		return nil, err
+	}
+	return New(_durations, onLoop...), nil
+}
+
+// NewTicks returns a new timeline in tick mode: the durations of
+// the frames are counts of ticks of the specified length and the
+// timeline is meant to be updated with UpdateTicks.
+func NewTicks(ticks []int, tick time.Duration, onLoop ...OnLoop) *Timeline {
+	durations := make([]time.Duration, len(ticks))
+	for i, n := range ticks {
+		durations[i] = tick * time.Duration(n)
+	}
+	tl := New(durations, onLoop...)
+	tl.tick = tick
+	return tl
+}
+
+// ParseTicks returns a new timeline of frameCount frames in tick
+// mode. ticks are the same as ParseTickDurations.
+func ParseTicks(ticks interface{}, frameCount int, tick time.Duration, onLoop ...OnLoop) (*Timeline, error) {
+	durations, err := ParseTickDurations(ticks, frameCount, tick)
+	if err != nil {
+		return nil, err
+	}
+	tl := New(durations, onLoop...)
+	tl.tick = tick
+	return tl, nil
+}
+
+// Clone return a copied timeline.
+func (tl *Timeline) Clone() *Timeline {
+	new := *tl
+	if tl.events != nil {
+		new.events = make(map[int][]string, len(tl.events))
+		for index, names := range tl.events {
+			new.events[index] = append([]string(nil), names...)
+		}
+	}
+	return &new
+}
+
+// SetOnLoop sets the callback function called on loops.
+func (tl *Timeline) SetOnLoop(onLoop OnLoop) {
+	tl.onLoop = onLoop
+}
+
+// SetOnComplete sets the callback function called when the
+// timeline completes its repeat count.
+func (tl *Timeline) SetOnComplete(onComplete OnComplete) {
+	tl.onComplete = onComplete
+}
+
+// SetRepeat sets how many times the timeline is played before it
+// completes. Zero (the default) repeats it forever.
+// It also resets the count of the loops played so far.
+func (tl *Timeline) SetRepeat(count int) {
+	tl.repeat = count
+	tl.loops = 0
+}
+
+// Repeat returns how many times the timeline is played before it
+// completes. Zero means forever.
+func (tl *Timeline) Repeat() int {
+	return tl.repeat
+}
+
+// Loops returns how many times the timeline has looped since it
+// was started or its repeat count was set.
+func (tl *Timeline) Loops() int {
+	return tl.loops
+}
+
+// RemainingLoops returns how many more times the timeline will
+// loop before it completes, counting the one being played.
+// It returns -1 if the timeline repeats forever.
+func (tl *Timeline) RemainingLoops() int {
+	if tl.repeat <= 0 {
+		return -1
+	}
+	return tl.repeat - tl.loops
+}
+
+// IsComplete returns true if the timeline has been played as many
+// times as its repeat count.
+func (tl *Timeline) IsComplete() bool {
+	return tl.repeat > 0 && tl.loops >= tl.repeat
+}
+
+// Restart moves the timeline to its first frame, resets the count
+// of the loops and plays it.
+func (tl *Timeline) Restart() {
+	tl.player.Restart()
+	tl.loops = 0
+}
+
+// SetSpeed sets the multiplier applied to the deltas passed to
+// UpdateWithDelta. 1 is the normal speed, 0 freezes the timeline
+// and negative values play it backward.
+func (tl *Timeline) SetSpeed(speed float64) {
+	tl.speed = speed
+}
+
+// Speed returns the multiplier applied to the deltas.
+func (tl *Timeline) Speed() float64 {
+	return tl.speed
+}
+
+// SetDirection sets the direction in which the frames are played.
+// The timeline stays on the current frame and the timer is moved
+// to the start of it, unless the timer is still zero in which case
+// the timeline moves to the first frame played in the direction.
+func (tl *Timeline) SetDirection(direction Direction) {
+	tl.player.def = tl.player.def.WithDirection(direction)
+	if tl.player.timer == 0 {
+		tl.player.position = tl.player.def.startIndex()
+		return
+	}
+	tl.GoToFrame(tl.player.position + 1)
+}
+
+func (tl *Timeline) setDurations(durations []time.Duration) {
+	tl.player.def = NewDef(durations, tl.player.def.direction)
+}
+
+// UpdateWithDelta updates the timeline with the specified delta
+// multiplied by its speed.
+//
+// The onEnter callback and the onEvent callback (for the events)
+// are called for every frame entered, even the ones skipped over
+// by a large delta. The timeline is moved to each frame before its
+// callbacks are called, so they see the position and the timer of
+// the frame; if a callback pauses the timeline or moves it to
+// another frame, the rest of the delta is dropped.
+//
+// Playing backward (with a negative delta or speed) wraps around
+// the start of the cycle and passes a negative number of loops to
+// the onLoop callback. Those loops don't count toward the repeat
+// count.
+//
+// When the timeline has a repeat count and the delta spans the
+// end of the last loop, the onLoop callback gets only the loops
+// left and the timeline pauses at the end before onComplete is
+// called.
+func (tl *Timeline) UpdateWithDelta(elapsedTime time.Duration) {
+	if tl.speed != 1 {
+		elapsedTime = time.Duration(float64(elapsedTime) * tl.speed)
+	}
+	tl.update(elapsedTime)
+}
+
+// update updates the timeline with the delta, without the speed.
+func (tl *Timeline) update(elapsedTime time.Duration) {
+	if tl.player.status != Playing || tl.player.def.cycleDuration <= 0 || tl.IsComplete() {
+		return
+	}
+	if tl.onEnter != nil || tl.onEvent != nil && len(tl.events) > 0 {
+		tl.fireEvents(elapsedTime)
+		return
+	}
+	tl.advance(elapsedTime)
+}
+
+// advance moves the timer by the delta, counting the loops and
+// completing the timeline at the end of its last loop. It returns
+// false if the timeline has completed.
+func (tl *Timeline) advance(delta time.Duration) bool {
+	loops := tl.player.UpdateWithDelta(delta)
+	if loops == 0 {
+		return true
+	}
+	if tl.repeat > 0 && loops > 0 && tl.loops+loops >= tl.repeat {
+		loops = tl.repeat - tl.loops
+		tl.loops = tl.repeat
+		// the frames after the end of the last loop are never shown
+		tl.player.position, tl.player.timer = tl.player.def.endIndex(), tl.player.def.cycleDuration
+		(tl.onLoop)(tl, loops)
+		tl.complete()
+		return false
+	}
+	if loops > 0 {
+		tl.loops += loops
+	}
+	(tl.onLoop)(tl, loops)
+	return true
+}
+
+// floorDiv returns a / b rounded toward negative infinity.
+func floorDiv(a, b time.Duration) time.Duration {
+	q := a / b
+	if a < 0 && a%b != 0 {
+		q--
+	}
+	return q
+}
+
+func (tl *Timeline) complete() {
+	tl.PauseAtEnd()
+	if tl.onComplete != nil {
+		tl.onComplete(tl)
+	}
+}
+
+// SetDurations sets the durations of the timeline and rewinds it
+// to the first frame played in its direction.
+func (tl *Timeline) SetDurations(durations []time.Duration) {
+	tl.setDurations(durations)
+	tl.player.timer = 0
+	tl.player.position = tl.player.def.startIndex()
+}
+
+// ReplaceDurations sets the durations of the timeline keeping the
+// current frame and the time elapsed in it where possible.
+func (tl *Timeline) ReplaceDurations(durations []time.Duration) {
+	tl.ReplaceDef(NewDef(durations, tl.player.def.direction))
+}
+
+// Tick returns the length of a tick in tick mode, or zero.
+func (tl *Timeline) Tick() time.Duration {
+	return tl.tick
+}
+
+// UpdateTicks updates the timeline in tick mode by exactly n ticks,
+// backward if n is negative. The speed isn't applied so that the
+// timer stays a whole number of ticks.
+// It does nothing if the timeline is not in tick mode.
+func (tl *Timeline) UpdateTicks(n int) {
+	if tl.tick == 0 {
+		return
+	}
+	tl.update(tl.tick * time.Duration(n))
+}
+
+// TickTimer returns the timer in ticks in tick mode.
+func (tl *Timeline) TickTimer() int {
+	if tl.tick == 0 {
+		return 0
+	}
+	return int(tl.player.timer / tl.tick)
+}
+
+// Def returns the definition of the frames played.
+func (tl *Timeline) Def() *Def {
+	return tl.player.Def()
+}
+
+// Direction returns the direction in which the frames are played.
+func (tl *Timeline) Direction() Direction {
+	return tl.player.Direction()
+}
+
+// Length returns the number of frames.
+func (tl *Timeline) Length() int {
+	return tl.player.Length()
+}
+
+// Durations returns the durations of each frames.
+func (tl *Timeline) Durations() []time.Duration {
+	return tl.player.Durations()
+}
+
+// TotalDuration returns the total duration of the frames.
+func (tl *Timeline) TotalDuration() time.Duration {
+	return tl.player.TotalDuration()
+}
+
+// Cycle returns the time it takes to play all the frames once in
+// the direction.
+func (tl *Timeline) Cycle() time.Duration {
+	return tl.player.Cycle()
+}
+
+// ReplaceDef replaces the definition played by the timeline keeping
+// the current frame and the time elapsed in it where possible.
+func (tl *Timeline) ReplaceDef(def *Def) {
+	tl.player.ReplaceDef(def)
+}
+
+// Status returns the status of the timeline.
+func (tl *Timeline) Status() Status {
+	return tl.player.Status()
+}
+
+// Pause pauses the timeline.
+func (tl *Timeline) Pause() {
+	tl.player.Pause()
+}
+
+// Resume resumes the timeline.
+func (tl *Timeline) Resume() {
+	tl.player.Resume()
+}
+
+// Position returns the current position of the frame.
+// The position counts from 1 (not 0).
+func (tl *Timeline) Position() int {
+	return tl.player.Position()
+}
+
+// Index returns the index of the current frame.
+// The index counts from 0.
+func (tl *Timeline) Index() int {
+	return tl.player.Index()
+}
+
+// Timer returns the current accumulated times of current frame.
+func (tl *Timeline) Timer() time.Duration {
+	return tl.player.Timer()
+}
+
+// IsEnd returns true if the timeline is paused on the last frame
+// played in its direction.
+func (tl *Timeline) IsEnd() bool {
+	return tl.player.IsEnd()
+}
+
+// SetTime moves the timeline to the time in its cycle, clamped
+// between zero and the time it takes to play all the frames once.
+// No callback is called.
+func (tl *Timeline) SetTime(t time.Duration) {
+	tl.player.SetTime(t)
+}
+
+// Progress returns how much of the cycle has been played, from 0
+// to 1.
+func (tl *Timeline) Progress() float64 {
+	return tl.player.Progress()
+}
+
+// SetProgress moves the timeline to the progress of its cycle,
+// from 0 to 1.
+func (tl *Timeline) SetProgress(progress float64) {
+	tl.player.SetProgress(progress)
+}
+
+// RemainingFrameTime returns the time left before the timeline
+// leaves the current frame.
+func (tl *Timeline) RemainingFrameTime() time.Duration {
+	return tl.player.RemainingFrameTime()
+}
+
+// GoToFrame sets the position of the timeline and
+// sets the timer at the start of the frame.
+// With the ping-pong directions, the timer is set at the first
+// time the frame is shown in the cycle.
+func (tl *Timeline) GoToFrame(position int) {
+	tl.player.GoToFrame(position)
+}
+
+// PauseAtEnd pauses the timeline and set the position
+// to the last frame played in its direction.
+func (tl *Timeline) PauseAtEnd() {
+	tl.player.PauseAtEnd()
+}
+
+// PauseAtStart pauses the timeline and set the position
+// to the first frame played in its direction.
+func (tl *Timeline) PauseAtStart() {
+	tl.player.PauseAtStart()
+}
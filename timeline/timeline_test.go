@@ -0,0 +1,426 @@
+package timeline_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2/timeline"
+)
+
+func d(s time.Duration) time.Duration {
+	return s * time.Second
+}
+
+func TestParseDurations(t *testing.T) {
+	var tests = []struct {
+		name      string
+		durations interface{}
+		want      []time.Duration
+		wantErr   bool
+	}{
+		{"reads a single duration", d(1), []time.Duration{d(1), d(1), d(1)}, false},
+		{"reads milliseconds", 100, []time.Duration{
+			time.Millisecond * 100, time.Millisecond * 100, time.Millisecond * 100,
+		}, false},
+		{"reads ranges", map[string]time.Duration{"1": d(1), "2-3": d(2)},
+			[]time.Duration{d(1), d(2), d(2)}, false},
+		{"fails on frames out of range", map[string]time.Duration{"2-4": d(1)}, nil, true},
+		{"fails on invalid values", "a", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timeline.ParseDurations(tt.durations, 3)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateWithDelta(t *testing.T) {
+	var tests = []struct {
+		name      string
+		durations []time.Duration
+		delta     time.Duration
+		want      int
+		wantLoops int
+	}{
+		{"moves to the next frame", []time.Duration{d(1), d(1), d(1), d(1)}, d(1), 2, 0},
+		{"loops back to the first frame", []time.Duration{d(1), d(1), d(1), d(1)}, d(4), 1, 1},
+		{"handles different durations", []time.Duration{d(1), d(2), d(3), d(4)}, d(6), 4, 0},
+		{"counts several loops", []time.Duration{d(1), d(1)}, d(5), 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loops := 0
+			tl := timeline.New(tt.durations, func(tl *timeline.Timeline, n int) {
+				loops += n
+			})
+			tl.UpdateWithDelta(tt.delta)
+			require.Equal(t, tt.want, tl.Position())
+			require.Equal(t, tt.wantLoops, loops)
+		})
+	}
+}
+
+func TestPauseAndResume(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(1), d(1)})
+	tl.Pause()
+	tl.UpdateWithDelta(d(1))
+	require.Equal(t, 1, tl.Position())
+	require.Equal(t, timeline.Paused, tl.Status())
+
+	tl.Resume()
+	tl.UpdateWithDelta(d(1))
+	require.Equal(t, 2, tl.Position())
+
+	tl.PauseAtEnd()
+	require.True(t, tl.IsEnd())
+	require.Equal(t, 3, tl.Position())
+}
+
+func TestReplaceDurations(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(1), d(1)})
+	tl.UpdateWithDelta(time.Millisecond * 2500)
+	tl.ReplaceDurations([]time.Duration{d(2), d(2), d(2)})
+	require.Equal(t, 3, tl.Position())
+	require.Equal(t, time.Millisecond*4500, tl.Timer())
+
+	tl.ReplaceDurations([]time.Duration{d(1), d(1)})
+	require.Equal(t, 2, tl.Position())
+	require.Equal(t, d(1), tl.Timer())
+}
+
+func TestDirection(t *testing.T) {
+	durations := []time.Duration{d(1), d(2), d(3), d(4)}
+	var tests = []struct {
+		name      string
+		direction timeline.Direction
+		cycle     time.Duration
+		want      []int
+		end       int
+	}{
+		{"plays forward", timeline.Forward, d(10),
+			[]int{1, 2, 2, 3, 3, 3, 4, 4, 4, 4}, 4},
+		{"plays in reverse", timeline.Reverse, d(10),
+			[]int{4, 4, 4, 4, 3, 3, 3, 2, 2, 1}, 1},
+		{"plays ping-pong", timeline.PingPong, d(15),
+			[]int{1, 2, 2, 3, 3, 3, 4, 4, 4, 4, 3, 3, 3, 2, 2}, 2},
+		{"plays ping-pong in reverse", timeline.PingPongReverse, d(15),
+			[]int{4, 4, 4, 4, 3, 3, 3, 2, 2, 1, 2, 2, 3, 3, 3}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loops := 0
+			tl := timeline.New(durations, func(tl *timeline.Timeline, n int) {
+				loops += n
+			})
+			tl.SetDirection(tt.direction)
+			require.Equal(t, tt.want[0], tl.Position())
+			for i, want := range tt.want {
+				tl.PauseAtStart()
+				tl.Resume()
+				tl.UpdateWithDelta(d(time.Duration(i)))
+				require.Equal(t, want, tl.Position(), "at %ds", i)
+			}
+			tl.UpdateWithDelta(tt.cycle)
+			require.Equal(t, 1, loops)
+
+			tl.PauseAtEnd()
+			require.Equal(t, tt.end, tl.Position())
+			require.True(t, tl.IsEnd())
+		})
+	}
+}
+
+func TestGoToFrameWithDirection(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(2), d(3), d(4)})
+	tl.SetDirection(timeline.Reverse)
+	tl.GoToFrame(2)
+	require.Equal(t, d(7), tl.Timer())
+	tl.UpdateWithDelta(d(2))
+	require.Equal(t, 1, tl.Position())
+}
+
+func TestRepeat(t *testing.T) {
+	var tests = []struct {
+		name      string
+		direction timeline.Direction
+		deltas    []time.Duration
+		wantLoops int
+		end       int
+	}{
+		{"completes after the last loop", timeline.Forward, []time.Duration{d(3), d(3), d(3)}, 3, 3},
+		{"completes with a delta spanning several loops", timeline.Forward, []time.Duration{d(1), d(100)}, 3, 3},
+		{"completes at the end of the direction", timeline.PingPong, []time.Duration{d(100)}, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loops, completed := 0, 0
+			tl := timeline.New([]time.Duration{d(1), d(1), d(1)}, func(tl *timeline.Timeline, n int) {
+				loops += n
+			})
+			tl.SetOnComplete(func(tl *timeline.Timeline) {
+				completed++
+			})
+			tl.SetDirection(tt.direction)
+			tl.SetRepeat(3)
+			require.Equal(t, 3, tl.RemainingLoops())
+			for _, delta := range tt.deltas {
+				tl.UpdateWithDelta(delta)
+			}
+			require.Equal(t, tt.wantLoops, loops)
+			require.Equal(t, 1, completed)
+			require.Equal(t, 0, tl.RemainingLoops())
+			require.True(t, tl.IsComplete())
+			require.True(t, tl.IsEnd())
+			require.Equal(t, tt.end, tl.Position())
+
+			tl.Resume()
+			tl.UpdateWithDelta(d(100))
+			require.Equal(t, 1, completed)
+
+			tl.Restart()
+			require.Equal(t, 3, tl.RemainingLoops())
+			tl.UpdateWithDelta(d(1))
+			require.Equal(t, 2, tl.Position())
+			require.Equal(t, 0, tl.Loops())
+		})
+	}
+}
+
+func TestRepeatSingleFrame(t *testing.T) {
+	loops, completed := 0, 0
+	tl := timeline.New([]time.Duration{d(2)}, func(tl *timeline.Timeline, n int) {
+		loops += n
+	})
+	tl.SetOnComplete(func(tl *timeline.Timeline) {
+		completed++
+	})
+	tl.SetRepeat(2)
+	tl.UpdateWithDelta(d(3))
+	require.Equal(t, 1, loops)
+	require.Equal(t, 1, tl.Loops())
+	require.False(t, tl.IsComplete())
+
+	tl.UpdateWithDelta(d(1))
+	require.Equal(t, 2, loops)
+	require.Equal(t, 1, completed)
+	require.True(t, tl.IsComplete())
+	require.True(t, tl.IsEnd())
+
+	p := timeline.NewPlayer(timeline.NewDef([]time.Duration{d(2)}, timeline.Forward))
+	require.Equal(t, 2, p.UpdateWithDelta(d(5)))
+	require.Equal(t, d(1), p.Timer())
+}
+
+func TestRepeatForever(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(1)})
+	require.Equal(t, -1, tl.RemainingLoops())
+	tl.UpdateWithDelta(d(100))
+	require.Equal(t, 50, tl.Loops())
+	require.False(t, tl.IsComplete())
+	require.Equal(t, timeline.Playing, tl.Status())
+}
+
+func TestEvents(t *testing.T) {
+	var tests = []struct {
+		name      string
+		direction timeline.Direction
+		repeat    int
+		deltas    []time.Duration
+		want      []string
+	}{
+		{"fires on entering the frames", timeline.Forward, 0,
+			[]time.Duration{d(1), d(1)}, []string{"hit:2", "range:2", "range:3"}},
+		{"fires on every skipped frame in order", timeline.Forward, 0,
+			[]time.Duration{d(5)}, []string{"hit:2", "range:2", "range:3", "range:4", "start:1", "hit:2", "range:2"}},
+		{"fires in the direction", timeline.Reverse, 0,
+			[]time.Duration{d(3)}, []string{"range:3", "hit:2", "range:2", "start:1"}},
+		{"fires in ping-pong", timeline.PingPong, 0,
+			[]time.Duration{d(6)}, []string{"hit:2", "range:2", "range:3", "range:4", "range:3", "hit:2", "range:2", "start:1"}},
+		{"fires backward with a negative delta", timeline.Forward, 0,
+			[]time.Duration{-d(2)}, []string{"range:4", "range:3"}},
+		{"stops at the end of the last loop", timeline.Forward, 1,
+			[]time.Duration{d(100)}, []string{"hit:2", "range:2", "range:3", "range:4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl := timeline.New([]time.Duration{d(1), d(1), d(1), d(1)})
+			require.NoError(t, tl.AddEvent("start", 1))
+			require.NoError(t, tl.AddEvent("hit", 2))
+			require.NoError(t, tl.AddEvent("range", "2-4"))
+			got := []string{}
+			tl.SetOnEvent(func(tl *timeline.Timeline, event string, position int) {
+				got = append(got, fmt.Sprintf("%s:%d", event, position))
+			})
+			tl.SetDirection(tt.direction)
+			tl.SetRepeat(tt.repeat)
+			for _, delta := range tt.deltas {
+				tl.UpdateWithDelta(delta)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOnEnter(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(1), d(1)})
+	got := []int{}
+	tl.SetOnEnter(func(tl *timeline.Timeline, position int) {
+		got = append(got, position)
+	})
+	tl.UpdateWithDelta(d(4))
+	require.Equal(t, []int{2, 3, 1, 2}, got)
+}
+
+func TestEventCallbacks(t *testing.T) {
+	var tests = []struct {
+		name         string
+		react        func(tl *timeline.Timeline)
+		wantPosition int
+		wantTimer    time.Duration
+		wantStatus   timeline.Status
+	}{
+		{"sees the frame of the event", func(tl *timeline.Timeline) {}, 2, d(1), timeline.Playing},
+		{"pauses on the frame of the event", (*timeline.Timeline).Pause, 3, d(2), timeline.Paused},
+		{"moves to another frame", func(tl *timeline.Timeline) { tl.GoToFrame(1) }, 1, 0, timeline.Playing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl := timeline.New([]time.Duration{d(1), d(1), d(1), d(1)})
+			require.NoError(t, tl.AddEvent("hit", 3))
+			seen := [][2]interface{}{}
+			tl.SetOnEvent(func(tl *timeline.Timeline, event string, position int) {
+				seen = append(seen, [2]interface{}{tl.Position(), tl.Timer()})
+				tt.react(tl)
+			})
+			tl.UpdateWithDelta(d(5))
+			require.Equal(t, [2]interface{}{3, d(2)}, seen[0])
+			require.Equal(t, tt.wantPosition, tl.Position())
+			require.Equal(t, tt.wantTimer, tl.Timer())
+			require.Equal(t, tt.wantStatus, tl.Status())
+		})
+	}
+}
+
+func TestAddEventErrors(t *testing.T) {
+	tl := timeline.New([]time.Duration{d(1), d(1)})
+	require.Error(t, tl.AddEvent("a", 3))
+	require.Error(t, tl.AddEvent("a", "1-3"))
+	require.Error(t, tl.AddEvent("a", "x"))
+	require.Empty(t, tl.Events(1))
+
+	require.NoError(t, tl.AddEvent("a", "2-1"))
+	require.NoError(t, tl.AddEvent("b", 2))
+	require.Equal(t, []string{"a", "b"}, tl.Events(2))
+	tl.RemoveEvent("a")
+	require.Equal(t, []string{"b"}, tl.Events(2))
+	require.Empty(t, tl.Events(1))
+}
+
+func TestSeek(t *testing.T) {
+	var tests = []struct {
+		name      string
+		direction timeline.Direction
+		time      time.Duration
+		want      int
+		progress  float64
+		remaining time.Duration
+	}{
+		{"seeks into a frame", timeline.Forward, time.Millisecond * 3500, 3, 0.35, time.Millisecond * 2500},
+		{"clamps before the start", timeline.Forward, -d(1), 1, 0, d(1)},
+		{"clamps after the end", timeline.Forward, d(20), 4, 1, 0},
+		{"seeks in the direction", timeline.Reverse, d(5), 3, 0.5, d(2)},
+		{"seeks in ping-pong", timeline.PingPong, d(12), 3, 0.8, d(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl := timeline.New([]time.Duration{d(1), d(2), d(3), d(4)})
+			tl.SetDirection(tt.direction)
+			tl.SetTime(tt.time)
+			require.Equal(t, tt.want, tl.Position())
+			require.InDelta(t, tt.progress, tl.Progress(), 1e-9)
+			require.Equal(t, tt.remaining, tl.RemainingFrameTime())
+
+			tl.SetProgress(tl.Progress())
+			require.Equal(t, tt.want, tl.Position())
+		})
+	}
+}
+
+func TestTicks(t *testing.T) {
+	tick := time.Second / 60
+	durations, err := timeline.ParseTickDurations(map[string]int{"1": 3, "2-3": 2}, 3, tick)
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{tick * 3, tick * 2, tick * 2}, durations)
+
+	loops := 0
+	tl := timeline.NewTicks([]int{3, 2, 2}, tick, func(tl *timeline.Timeline, n int) {
+		loops += n
+	})
+	want := []int{1, 1, 1, 2, 2, 3, 3}
+	for i := 0; i < 7*600; i++ {
+		tl.UpdateTicks(1)
+		require.Equal(t, want[(i+1)%7], tl.Position(), "at tick %d", i+1)
+	}
+	require.Equal(t, 600, loops)
+	require.Equal(t, 0, tl.TickTimer())
+
+	tl.UpdateTicks(4)
+	require.Equal(t, 4, tl.TickTimer())
+	require.Equal(t, 2, tl.Position())
+
+	// the speed would make the timer fall between two ticks
+	tl.SetSpeed(1.1)
+	tl.UpdateTicks(1)
+	require.Equal(t, tick*5, tl.Timer())
+
+	_, err = timeline.ParseTickDurations([]interface{}{2.0, 1.5}, 2, tick)
+	require.Error(t, err)
+	_, err = timeline.ParseTickDurations(time.Millisecond, 2, tick)
+	require.Error(t, err)
+	_, err = timeline.ParseTicks(map[string]interface{}{"1-2": 2.0}, 2, tick)
+	require.NoError(t, err)
+}
+
+func TestPlayer(t *testing.T) {
+	def := timeline.NewDef([]time.Duration{d(1), d(1), d(1)}, timeline.PingPong)
+	require.Equal(t, d(4), def.Cycle())
+
+	var tests = []struct {
+		delta    time.Duration
+		position int
+		loops    int
+	}{
+		{d(1), 2, 0},
+		{d(1), 3, 0},
+		{d(1), 2, 0},
+		{d(2), 2, 1},
+		{-d(3), 3, -1},
+	}
+	p := timeline.NewPlayer(def)
+	for _, tt := range tests {
+		require.Equal(t, tt.loops, p.UpdateWithDelta(tt.delta))
+		require.Equal(t, tt.position, p.Position())
+	}
+
+	reverse := timeline.NewPlayer(def.WithDirection(timeline.Reverse))
+	require.Equal(t, 3, reverse.Position())
+	require.Equal(t, timeline.PingPong, def.Direction())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		p := timeline.NewPlayer(def)
+		p.UpdateWithDelta(d(5))
+	})
+	require.Zero(t, allocs)
+
+	tl := timeline.NewFromDef(def)
+	tl.SetDurations([]time.Duration{d(2), d(2)})
+	require.Equal(t, d(3), def.TotalDuration())
+}
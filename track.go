@@ -0,0 +1,197 @@
+package ganim8
+
+import (
+	"sort"
+	"time"
+)
+
+// Property is a property of the draw options animated by a track.
+type Property int
+
+const (
+	// PropertyX and PropertyY are added to the position.
+	PropertyX Property = iota
+	PropertyY
+	// PropertyRotate is added to the rotation, in radians.
+	PropertyRotate
+	// PropertyScaleX and PropertyScaleY multiply the scale.
+	PropertyScaleX
+	PropertyScaleY
+	// PropertyAlpha multiplies the alpha of the color matrix.
+	PropertyAlpha
+	// PropertyTintR, PropertyTintG and PropertyTintB multiply the
+	// color channels of the color matrix.
+	PropertyTintR
+	PropertyTintG
+	PropertyTintB
+)
+
+// Interpolation maps the progress between two keyframes, from 0
+// to 1, to the progress of the value from the first keyframe to
+// the second.
+type Interpolation func(t float64) float64
+
+// Linear moves the value at a constant rate.
+func Linear(t float64) float64 {
+	return t
+}
+
+// Step holds the value of a keyframe until the next one.
+func Step(t float64) float64 {
+	return 0
+}
+
+// Smooth eases the value in and out of the keyframes.
+func Smooth(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
+
+// Keyframe is the value of a track at a time of the cycle of the
+// animation. The interpolation is used toward the next keyframe,
+// Linear if it is nil.
+type Keyframe struct {
+	Time          time.Duration
+	Value         float64
+	Interpolation Interpolation
+}
+
+// Track animates a property of the draw options with keyframes.
+type Track struct {
+	property  Property
+	keyframes []Keyframe
+}
+
+// NewTrack returns a new track of the property with the keyframes,
+// which don't need to be sorted by time.
+func NewTrack(property Property, keyframes ...Keyframe) *Track {
+	t := &Track{property: property}
+	for _, k := range keyframes {
+		t.Add(k)
+	}
+	return t
+}
+
+// Add adds the keyframe to the track, replacing the one at the same
+// time if any.
+func (t *Track) Add(k Keyframe) *Track {
+	i := sort.Search(len(t.keyframes), func(i int) bool {
+		return t.keyframes[i].Time >= k.Time
+	})
+	if i < len(t.keyframes) && t.keyframes[i].Time == k.Time {
+		t.keyframes[i] = k
+		return t
+	}
+	t.keyframes = append(t.keyframes, Keyframe{})
+	copy(t.keyframes[i+1:], t.keyframes[i:])
+	t.keyframes[i] = k
+	return t
+}
+
+// Property returns the property animated by the track.
+func (t *Track) Property() Property {
+	return t.property
+}
+
+// Keyframes returns the keyframes of the track sorted by time.
+func (t *Track) Keyframes() []Keyframe {
+	return t.keyframes
+}
+
+// Value returns the value of the track at the time. It is the value
+// of the first keyframe before it and the one of the last keyframe
+// after it.
+func (t *Track) Value(at time.Duration) float64 {
+	n := len(t.keyframes)
+	if n == 0 {
+		return t.neutral()
+	}
+	i := sort.Search(n, func(i int) bool {
+		return t.keyframes[i].Time > at
+	})
+	if i == 0 {
+		return t.keyframes[0].Value
+	}
+	if i == n {
+		return t.keyframes[n-1].Value
+	}
+	from, to := t.keyframes[i-1], t.keyframes[i]
+	interpolation := from.Interpolation
+	if interpolation == nil {
+		interpolation = Linear
+	}
+	p := interpolation(float64(at-from.Time) / float64(to.Time-from.Time))
+	return from.Value + (to.Value-from.Value)*p
+}
+
+// neutral returns the value that leaves the property unchanged.
+func (t *Track) neutral() float64 {
+	switch t.property {
+	case PropertyX, PropertyY, PropertyRotate:
+		return 0
+	}
+	return 1
+}
+
+// Apply applies the value of the track at the time to the options.
+func (t *Track) Apply(opts *DrawOptions, at time.Duration) {
+	v := t.Value(at)
+	switch t.property {
+	case PropertyX:
+		opts.X += v
+	case PropertyY:
+		opts.Y += v
+	case PropertyRotate:
+		opts.Rotate += v
+	case PropertyScaleX:
+		opts.ScaleX *= v
+	case PropertyScaleY:
+		opts.ScaleY *= v
+	case PropertyAlpha:
+		opts.ColorM.Scale(1, 1, 1, v)
+	case PropertyTintR:
+		opts.ColorM.Scale(v, 1, 1, 1)
+	case PropertyTintG:
+		opts.ColorM.Scale(1, v, 1, 1)
+	case PropertyTintB:
+		opts.ColorM.Scale(1, 1, v, 1)
+	}
+}
+
+// AddTrack adds the track to the animation. The tracks are
+// evaluated at the time of the animation in its cycle and applied
+// to the options every time the animation is drawn, in the order
+// they were added.
+func (anim *Animation) AddTrack(t *Track) {
+	anim.tracks = append(anim.tracks, t)
+}
+
+// RemoveTracks removes the tracks of the property.
+func (anim *Animation) RemoveTracks(property Property) {
+	kept := anim.tracks[:0]
+	for _, t := range anim.tracks {
+		if t.property != property {
+			kept = append(kept, t)
+		}
+	}
+	anim.tracks = kept
+}
+
+// Tracks returns the tracks of the animation.
+func (anim *Animation) Tracks() []*Track {
+	return anim.tracks
+}
+
+// TrackOptions returns the options with the tracks of the animation
+// applied, the ones the animation is drawn with. The options are
+// not modified and the result is only valid until the next call.
+func (anim *Animation) TrackOptions(opts *DrawOptions) *DrawOptions {
+	if len(anim.tracks) == 0 {
+		return opts
+	}
+	anim.trackOpts = *opts
+	at := anim.timeline.Timer()
+	for _, t := range anim.tracks {
+		t.Apply(&anim.trackOpts, at)
+	}
+	return &anim.trackOpts
+}
@@ -0,0 +1,65 @@
+package ganim8_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestTrackValue(t *testing.T) {
+	track := ganim8.NewTrack(ganim8.PropertyX,
+		ganim8.Keyframe{Time: time.Second * 4, Value: 20},
+		ganim8.Keyframe{Time: time.Second * 2, Value: 10, Interpolation: ganim8.Step},
+		ganim8.Keyframe{Time: 0, Value: 0},
+	)
+	var tests = []struct {
+		at   time.Duration
+		want float64
+	}{
+		{-time.Second, 0},
+		{0, 0},
+		{time.Second, 5},
+		{time.Second * 2, 10},
+		{time.Second * 3, 10},
+		{time.Second * 4, 20},
+		{time.Second * 5, 20},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, track.Value(tt.at), "at %v", tt.at)
+	}
+
+	track.Add(ganim8.Keyframe{Time: time.Second * 2, Value: 0, Interpolation: ganim8.Smooth})
+	require.Len(t, track.Keyframes(), 3)
+	require.Equal(t, 10.0, track.Value(time.Second*3))
+
+	require.Equal(t, 1.0, ganim8.NewTrack(ganim8.PropertyAlpha).Value(0))
+}
+
+func TestTracks(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, red)
+	r1, r2 := image.Rect(0, 0, 1, 1), image.Rect(1, 0, 2, 1)
+	anim := ganim8.NewAnimation(ganim8.NewSpriteFromImage(src, []*image.Rectangle{&r1, &r2}), time.Second)
+	anim.AddTrack(ganim8.NewTrack(ganim8.PropertyX,
+		ganim8.Keyframe{Time: 0, Value: 0},
+		ganim8.Keyframe{Time: time.Second * 2, Value: 4},
+	))
+	anim.AddTrack(ganim8.NewTrack(ganim8.PropertyAlpha, ganim8.Keyframe{Value: 0.5}))
+	anim.UpdateWithDelta(time.Second)
+
+	opts := ganim8.DrawOpts(1, 1)
+	dst := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	anim.Render(ganim8.NewCPURenderer(dst), opts)
+	require.Equal(t, color.RGBA{0x7f, 0, 0, 0x7f}, dst.At(3, 1))
+	require.Equal(t, color.RGBA{}, dst.At(1, 1))
+	require.Equal(t, 1.0, opts.X)
+
+	anim.RemoveTracks(ganim8.PropertyX)
+	require.Len(t, anim.Tracks(), 1)
+	require.Equal(t, 1.0, anim.TrackOptions(opts).X)
+}
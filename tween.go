@@ -0,0 +1,138 @@
+package ganim8
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/exp/constraints"
+)
+
+// Lerp is a function interpolating between two values of a tween,
+// t going from 0 to 1 (or past them with easings that overshoot).
+type Lerp[T any] func(from, to T, t float64) T
+
+// Tween moves a value from a start to an end over a duration with
+// an easing, updated with deltas like an animation.
+type Tween[T any] struct {
+	from     T
+	to       T
+	duration time.Duration
+	elapsed  time.Duration
+	ease     Interpolation
+	lerp     Lerp[T]
+	value    T
+	target   *T
+}
+
+// NewTween returns a new tween of a number from a value to another
+// over the duration. The easing is Linear if it is nil.
+func NewTween[T constraints.Integer | constraints.Float](from, to T, duration time.Duration, ease Interpolation) *Tween[T] {
+	return NewTweenFunc(from, to, duration, ease, LerpNumber[T])
+}
+
+// NewTweenFunc returns a new tween of any value interpolated with
+// the lerp function, e.g. LerpDrawOptions or LerpColorM.
+func NewTweenFunc[T any](from, to T, duration time.Duration, ease Interpolation, lerp Lerp[T]) *Tween[T] {
+	if ease == nil {
+		ease = Linear
+	}
+	tw := &Tween[T]{from: from, to: to, duration: duration, ease: ease, lerp: lerp}
+	tw.set()
+	return tw
+}
+
+// Bind makes the tween write its value to the target, e.g. a field
+// of DrawOptions, every time it is updated, starting now.
+func (tw *Tween[T]) Bind(target *T) *Tween[T] {
+	tw.target = target
+	tw.set()
+	return tw
+}
+
+// Value returns the current value of the tween.
+func (tw *Tween[T]) Value() T {
+	return tw.value
+}
+
+// Progress returns how much of the duration has elapsed, from 0
+// to 1.
+func (tw *Tween[T]) Progress() float64 {
+	if tw.duration <= 0 {
+		return 1
+	}
+	return float64(tw.elapsed) / float64(tw.duration)
+}
+
+// IsDone returns true once the tween reached its end value.
+func (tw *Tween[T]) IsDone() bool {
+	return tw.elapsed >= tw.duration
+}
+
+// Reset moves the tween back to its start value.
+func (tw *Tween[T]) Reset() {
+	tw.elapsed = 0
+	tw.set()
+}
+
+// Update updates the tween with the delta of Animation.Update.
+func (tw *Tween[T]) Update() {
+	tw.UpdateWithDelta(time.Duration(float64(DefaultDelta) * TimeScale))
+}
+
+// UpdateWithDelta moves the tween by the delta, clamped to its
+// duration.
+func (tw *Tween[T]) UpdateWithDelta(elapsedTime time.Duration) {
+	tw.elapsed += elapsedTime
+	if tw.elapsed > tw.duration {
+		tw.elapsed = tw.duration
+	}
+	if tw.elapsed < 0 {
+		tw.elapsed = 0
+	}
+	tw.set()
+}
+
+func (tw *Tween[T]) set() {
+	switch {
+	case tw.elapsed >= tw.duration:
+		tw.value = tw.to
+	case tw.elapsed <= 0:
+		tw.value = tw.from
+	default:
+		tw.value = tw.lerp(tw.from, tw.to, tw.ease(tw.Progress()))
+	}
+	if tw.target != nil {
+		*tw.target = tw.value
+	}
+}
+
+// LerpNumber interpolates linearly between two numbers.
+func LerpNumber[T constraints.Integer | constraints.Float](from, to T, t float64) T {
+	return T(float64(from) + (float64(to)-float64(from))*t)
+}
+
+// LerpColorM interpolates every element of two color matrices.
+func LerpColorM(from, to ebiten.ColorM, t float64) ebiten.ColorM {
+	var m ebiten.ColorM
+	for i := 0; i < ebiten.ColorMDim-1; i++ {
+		for j := 0; j < ebiten.ColorMDim; j++ {
+			m.SetElement(i, j, LerpNumber(from.Element(i, j), to.Element(i, j), t))
+		}
+	}
+	return m
+}
+
+// LerpDrawOptions interpolates the position, the rotation, the
+// scale, the origin and the color matrix of two options. The
+// composite mode is the one of the start until the end.
+func LerpDrawOptions(from, to DrawOptions, t float64) DrawOptions {
+	from.X = LerpNumber(from.X, to.X, t)
+	from.Y = LerpNumber(from.Y, to.Y, t)
+	from.Rotate = LerpNumber(from.Rotate, to.Rotate, t)
+	from.ScaleX = LerpNumber(from.ScaleX, to.ScaleX, t)
+	from.ScaleY = LerpNumber(from.ScaleY, to.ScaleY, t)
+	from.OriginX = LerpNumber(from.OriginX, to.OriginX, t)
+	from.OriginY = LerpNumber(from.OriginY, to.OriginY, t)
+	from.ColorM = LerpColorM(from.ColorM, to.ColorM, t)
+	return from
+}
@@ -0,0 +1,59 @@
+package ganim8_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/yohamta/ganim8/v2"
+)
+
+func TestTween(t *testing.T) {
+	var x float64
+	tw := ganim8.NewTween(10.0, 20.0, time.Second*2, nil).Bind(&x)
+	require.Equal(t, 10.0, x)
+
+	tw.UpdateWithDelta(time.Second)
+	require.Equal(t, 15.0, tw.Value())
+	require.Equal(t, 15.0, x)
+	require.Equal(t, 0.5, tw.Progress())
+	require.False(t, tw.IsDone())
+
+	tw.UpdateWithDelta(time.Second * 5)
+	require.Equal(t, 20.0, x)
+	require.True(t, tw.IsDone())
+
+	tw.Reset()
+	require.Equal(t, 10.0, x)
+
+	n := ganim8.NewTween(0, 100, time.Second, ganim8.EaseInQuad)
+	n.UpdateWithDelta(time.Second / 2)
+	require.Equal(t, 25, n.Value())
+
+	z := ganim8.NewTween(0, 10, 0, nil)
+	require.Equal(t, 10, z.Value())
+	require.True(t, z.IsDone())
+}
+
+func TestTweenDrawOptions(t *testing.T) {
+	from, to := *ganim8.DrawOpts(0, 0), *ganim8.DrawOpts(10, 20, 1, 3, 3)
+	to.ColorM.Scale(1, 1, 1, 0)
+	opts := from
+	tw := ganim8.NewTweenFunc(from, to, time.Second, ganim8.Linear, ganim8.LerpDrawOptions).Bind(&opts)
+	tw.UpdateWithDelta(time.Second / 2)
+
+	require.Equal(t, 5.0, opts.X)
+	require.Equal(t, 10.0, opts.Y)
+	require.Equal(t, 0.5, opts.Rotate)
+	require.Equal(t, 2.0, opts.ScaleX)
+	require.Equal(t, 0.5, opts.ColorM.Element(3, 3))
+	require.Equal(t, 1.0, opts.ColorM.Element(0, 0))
+
+	var alpha ebiten.ColorM
+	alpha.Scale(1, 1, 1, 0)
+	m := ganim8.NewTweenFunc(ebiten.ColorM{}, alpha, time.Second, ganim8.EaseOutQuad, ganim8.LerpColorM)
+	m.UpdateWithDelta(time.Second / 2)
+	value := m.Value()
+	require.Equal(t, 0.25, value.Element(3, 3))
+}